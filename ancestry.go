@@ -0,0 +1,124 @@
+package git
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MergeBaseOptions configures MergeBase.
+type MergeBaseOptions struct {
+	All     bool // All returns every best common ancestor instead of one (--all).
+	Octopus bool // Octopus computes the best common ancestors of all commits for an n-way merge (--octopus).
+}
+
+// MergeBase returns the best common ancestor of commits, or an empty slice if they have none.
+// opts may be nil.
+func (r *Repository) MergeBase(opts *MergeBaseOptions, commits ...string) ([]string, error) {
+	if len(commits) < 2 && (opts == nil || !opts.Octopus) {
+		return nil, errors.New("go-git: MergeBase() at least two commits must be specified")
+	}
+	if opts == nil {
+		opts = &MergeBaseOptions{}
+	}
+	args := []string{"merge-base"}
+	if opts.All {
+		args = append(args, "--all")
+	}
+	if opts.Octopus {
+		args = append(args, "--octopus")
+	}
+	stdout, _, err := r.run(append(args, commits...)...)
+	if err != nil {
+		// merge-base exits with 1 when there is no common ancestor.
+		if exitStatus(err) == 1 {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	return strings.Fields(stdout), nil
+}
+
+// IsAncestor reports whether ancestor is an ancestor of, or the same commit as, commit.
+func (r *Repository) IsAncestor(ancestor, commit string) (bool, error) {
+	if ancestor == "" || commit == "" {
+		return false, errors.New("go-git: IsAncestor() two commits must be specified")
+	}
+	_, _, err := r.run("merge-base", "--is-ancestor", ancestor, commit)
+	if err != nil {
+		if exitStatus(err) == 1 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// AheadBehind returns the number of commits in a that are not in b, and in b that are not in a.
+func (r *Repository) AheadBehind(a, b string) (ahead, behind int, err error) {
+	if a == "" || b == "" {
+		return 0, 0, errors.New("go-git: AheadBehind() two commits must be specified")
+	}
+	stdout, _, err := r.run("rev-list", "--left-right", "--count", a+"..."+b)
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseLeftRightCount(stdout)
+}
+
+// parseLeftRightCount parses the output of git rev-list --left-right --count.
+func parseLeftRightCount(out string) (left, right int, err error) {
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, errors.New("go-git: unexpected rev-list output " + strconv.Quote(out))
+	}
+	if left, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, err
+	}
+	if right, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, err
+	}
+	return left, right, nil
+}
+
+// Divergence is how far a reference has diverged from a base.
+type Divergence struct {
+	Ref    string
+	Ahead  int // Ahead is the number of commits in Ref that are not in the base.
+	Behind int // Behind is the number of commits in the base that are not in Ref.
+}
+
+// AheadBehindAll computes how far each of refs has diverged from base, running the
+// comparisons in parallel. The results are in the order of refs.
+func (r *Repository) AheadBehindAll(base string, refs ...string) ([]Divergence, error) {
+	if base == "" {
+		return nil, errors.New("go-git: AheadBehindAll() no base specified")
+	}
+	results := make([]Divergence, len(refs))
+	errs := make([]error, len(refs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU() && w < len(refs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].Ref = refs[i]
+				results[i].Ahead, results[i].Behind, errs[i] = r.AheadBehind(refs[i], base)
+			}
+		}()
+	}
+	for i := range refs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
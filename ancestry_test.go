@@ -0,0 +1,88 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergeBaseArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Opts       *MergeBaseOptions
+		Commits    []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "Single commit",
+			Commits:    []string{"main"},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: MergeBase() at least two commits must be specified"),
+		},
+		{
+			CaseName:   "Two commits",
+			Commits:    []string{"main", "feature"},
+			ExpectArgs: []string{"merge-base", "main", "feature"},
+		},
+		{
+			CaseName:   "All octopus bases",
+			Opts:       &MergeBaseOptions{All: true, Octopus: true},
+			Commits:    []string{"main", "feature-1", "feature-2"},
+			ExpectArgs: []string{"merge-base", "--all", "--octopus", "main", "feature-1", "feature-2"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := (&Repository{}).MergeBase(c.Opts, c.Commits...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseLeftRightCount(t *testing.T) {
+	left, right, err := parseLeftRightCount("3\t12\n")
+	if left != 3 || right != 12 || err != nil {
+		t.Errorf("expected 3, 12, got %v, %v, %v", left, right, err)
+	}
+	if _, _, err := parseLeftRightCount(""); err == nil {
+		t.Error("expected an error for empty output")
+	}
+}
+
+func TestAncestry(t *testing.T) {
+	r := testRepository(t, "")
+	commit := func(msg string) string { return testCommit(t, r, msg) }
+	base := commit("base")
+	r.Branch("feature")
+	commit("main-1")
+	commit("main-2")
+	r.Checkout("feature")
+	commit("feature-1")
+
+	if got, err := r.MergeBase(nil, "main", "feature"); err != nil || !reflect.DeepEqual([]string{base}, got) {
+		t.Errorf("MergeBase: expected %v, got %v, %v", base, got, err)
+	}
+	if got, err := r.IsAncestor(base, "feature"); err != nil || !got {
+		t.Errorf("IsAncestor: expected true, got %v, %v", got, err)
+	}
+	if got, err := r.IsAncestor("main", "feature"); err != nil || got {
+		t.Errorf("IsAncestor: expected false, got %v, %v", got, err)
+	}
+	if ahead, behind, err := r.AheadBehind("feature", "main"); err != nil || ahead != 1 || behind != 2 {
+		t.Errorf("AheadBehind: expected 1, 2, got %v, %v, %v", ahead, behind, err)
+	}
+	expect := []Divergence{{Ref: "feature", Ahead: 1, Behind: 2}, {Ref: base, Ahead: 0, Behind: 2}, {Ref: "main", Ahead: 0, Behind: 0}}
+	if got, err := r.AheadBehindAll("main", "feature", base, "main"); err != nil || !reflect.DeepEqual(expect, got) {
+		t.Errorf("AheadBehindAll\nexpected : %v\ngot      : %v, %v", expect, got, err)
+	}
+}
@@ -0,0 +1,161 @@
+package git
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameOptions configures Blame.
+type BlameOptions struct {
+	// Ranges restricts blame to line ranges, e.g. "10,20", "40,+5" or ":funcname" (-L).
+	Ranges           []string
+	IgnoreRevsFile   string   // IgnoreRevsFile ignores the revisions listed in the file, e.g. ".git-blame-ignore-revs" (--ignore-revs-file).
+	IgnoreRevs       []string // IgnoreRevs ignores the given revisions (--ignore-rev).
+	IgnoreWhitespace bool     // IgnoreWhitespace ignores whitespace changes (-w).
+	DetectMoves      bool     // DetectMoves attributes lines moved within the file to their origin (-M).
+	// DetectCopies attributes lines moved or copied from other files to their origin (-C).
+	// 1 looks at files modified in the same commit, 2 also at the commit creating the file
+	// and 3 at every commit.
+	DetectCopies int
+}
+
+// BlameLine attributes a line of a file to the commit that last changed it.
+type BlameLine struct {
+	Line           int       // Line is the line number in the blamed revision, starting at 1.
+	Content        string    // Content is the text of the line without the line terminator.
+	Commit         string    // Commit last changed the line.
+	OrigLine       int       // OrigLine is the line number in Commit.
+	OrigPath       string    // OrigPath is the path of the file in Commit.
+	Author         string    // Author is the author name of Commit.
+	AuthorEmail    string    // AuthorEmail is the author email without angle brackets.
+	AuthorTime     time.Time // AuthorTime is when Commit was authored, in the author's time zone.
+	Committer      string    // Committer is the committer name of Commit.
+	CommitterEmail string    // CommitterEmail is the committer email without angle brackets.
+	CommitterTime  time.Time // CommitterTime is when Commit was committed, in the committer's time zone.
+	Summary        string    // Summary is the first line of the commit message.
+	Boundary       bool      // Boundary is true when Commit is the boundary of the blamed range, e.g. a root commit.
+}
+
+// Blame attributes each line of the file at path, as of revision, to the commit that last changed it.
+// If revision is empty the working tree version of the file is blamed. opts may be nil.
+func (r *Repository) Blame(path, revision string, opts *BlameOptions) ([]BlameLine, error) {
+	if path == "" {
+		return nil, errors.New("go-git: Blame() no path specified")
+	}
+	if opts == nil {
+		opts = &BlameOptions{}
+	}
+	if opts.DetectCopies < 0 || opts.DetectCopies > 3 {
+		return nil, errors.New("go-git: Blame() DetectCopies must be between 0 and 3")
+	}
+	args := []string{"blame", "--porcelain"}
+	for _, l := range opts.Ranges {
+		args = append(args, "-L", l)
+	}
+	if opts.IgnoreRevsFile != "" {
+		args = append(args, "--ignore-revs-file", opts.IgnoreRevsFile)
+	}
+	for _, rev := range opts.IgnoreRevs {
+		args = append(args, "--ignore-rev", rev)
+	}
+	if opts.IgnoreWhitespace {
+		args = append(args, "-w")
+	}
+	if opts.DetectMoves {
+		args = append(args, "-M")
+	}
+	for i := 0; i < opts.DetectCopies; i++ {
+		args = append(args, "-C")
+	}
+	if revision != "" {
+		args = append(args, revision)
+	}
+	stdout, _, err := r.run(append(args, "--", path)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlamePorcelain(stdout)
+}
+
+// parseBlamePorcelain parses the output of git blame --porcelain. Each line is introduced by a
+// header holding the commit, the original and final line numbers, followed by the details of
+// the commit the first time it appears, and the content prefixed with a tab.
+func parseBlamePorcelain(out string) ([]BlameLine, error) {
+	lines := []BlameLine{}
+	commits := map[string]*BlameLine{}
+	var cur *BlameLine
+	for _, line := range strings.Split(out, "\n") {
+		if cur == nil {
+			if line == "" {
+				continue
+			}
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, errors.New("go-git: unexpected blame header " + line)
+			}
+			orig, err1 := strconv.Atoi(fields[1])
+			final, err2 := strconv.Atoi(fields[2])
+			if err1 != nil || err2 != nil {
+				return nil, errors.New("go-git: unexpected blame header " + line)
+			}
+			commit, ok := commits[fields[0]]
+			if !ok {
+				commit = &BlameLine{Commit: fields[0]}
+				commits[fields[0]] = commit
+			}
+			cur = commit
+			cur.OrigLine, cur.Line = orig, final
+			continue
+		}
+		if content, ok := strings.CutPrefix(line, "\t"); ok {
+			entry := *cur
+			entry.Content = content
+			lines = append(lines, entry)
+			cur = nil
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-mail":
+			cur.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			cur.AuthorTime = blameTime(value)
+		case "author-tz":
+			cur.AuthorTime = cur.AuthorTime.In(blameZone(value))
+		case "committer":
+			cur.Committer = value
+		case "committer-mail":
+			cur.CommitterEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "committer-time":
+			cur.CommitterTime = blameTime(value)
+		case "committer-tz":
+			cur.CommitterTime = cur.CommitterTime.In(blameZone(value))
+		case "summary":
+			cur.Summary = value
+		case "boundary":
+			cur.Boundary = true
+		case "filename":
+			cur.OrigPath = value
+		}
+	}
+	return lines, nil
+}
+
+// blameTime parses a Unix timestamp. The time zone follows on its own line.
+func blameTime(value string) time.Time {
+	sec, _ := strconv.ParseInt(value, 10, 64)
+	return time.Unix(sec, 0).UTC()
+}
+
+// blameZone parses a time zone offset such as "+0130".
+func blameZone(value string) *time.Location {
+	t, err := time.Parse("-0700", value)
+	if err != nil {
+		return time.UTC
+	}
+	return t.Location()
+}
@@ -0,0 +1,137 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBlameArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Path       string
+		Revision   string
+		Opts       *BlameOptions
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No path",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Blame() no path specified"),
+		},
+		{
+			CaseName:   "Working tree",
+			Path:       "main.go",
+			ExpectArgs: []string{"blame", "--porcelain", "--", "main.go"},
+		},
+		{
+			CaseName: "All options",
+			Path:     "main.go",
+			Revision: "v1.0.0",
+			Opts: &BlameOptions{
+				Ranges:           []string{"10,20", ":main"},
+				IgnoreRevsFile:   ".git-blame-ignore-revs",
+				IgnoreRevs:       []string{"abcd"},
+				IgnoreWhitespace: true,
+				DetectMoves:      true,
+				DetectCopies:     2,
+			},
+			ExpectArgs: []string{"blame", "--porcelain", "-L", "10,20", "-L", ":main", "--ignore-revs-file", ".git-blame-ignore-revs",
+				"--ignore-rev", "abcd", "-w", "-M", "-C", "-C", "v1.0.0", "--", "main.go"},
+		},
+		{
+			CaseName:   "Too many copy passes",
+			Path:       "main.go",
+			Opts:       &BlameOptions{DetectCopies: 4},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Blame() DetectCopies must be between 0 and 3"),
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := (&Repository{}).Blame(c.Path, c.Revision, c.Opts)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseBlamePorcelain(t *testing.T) {
+	out := "aaaa 1 1 1\nauthor Ann\nauthor-mail <ann@example.com>\nauthor-time 1700000000\nauthor-tz +0100\n" +
+		"committer Bob\ncommitter-mail <bob@example.com>\ncommitter-time 1700000100\ncommitter-tz -0500\n" +
+		"summary first\nboundary\nfilename old.go\n\tpackage main\n" +
+		"bbbb 2 2 1\nauthor Bob\nauthor-mail <bob@example.com>\nauthor-time 1700000200\nauthor-tz +0000\n" +
+		"committer Bob\ncommitter-mail <bob@example.com>\ncommitter-time 1700000200\ncommitter-tz +0000\n" +
+		"summary second\nprevious aaaa old.go\nfilename main.go\n\t\n" +
+		"aaaa 2 3 1\n\tfunc main() {}\n"
+	lines, err := parseBlamePorcelain(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %+v", lines)
+	}
+	first := lines[0]
+	if first.Line != 1 || first.OrigLine != 1 || first.Commit != "aaaa" || first.OrigPath != "old.go" || !first.Boundary ||
+		first.Author != "Ann" || first.AuthorEmail != "ann@example.com" || first.Committer != "Bob" ||
+		first.Summary != "first" || first.Content != "package main" {
+		t.Errorf("unexpected first line %+v", first)
+	}
+	if _, offset := first.AuthorTime.Zone(); offset != 3600 || first.AuthorTime.Unix() != 1700000000 {
+		t.Errorf("unexpected author time %v", first.AuthorTime)
+	}
+	if _, offset := first.CommitterTime.Zone(); offset != -5*3600 || !first.CommitterTime.Equal(time.Unix(1700000100, 0)) {
+		t.Errorf("unexpected committer time %v", first.CommitterTime)
+	}
+	if lines[1].Commit != "bbbb" || lines[1].Content != "" || lines[1].OrigPath != "main.go" || lines[1].Boundary {
+		t.Errorf("unexpected second line %+v", lines[1])
+	}
+	third := lines[2]
+	if third.Commit != "aaaa" || third.Line != 3 || third.OrigLine != 2 || third.Author != "Ann" || third.Content != "func main() {}" {
+		t.Errorf("unexpected third line %+v", third)
+	}
+
+	if _, err := parseBlamePorcelain("aaaa\n"); !equalErr(errors.New("go-git: unexpected blame header aaaa"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestBlame(t *testing.T) {
+	r := testRepository(t, "")
+	commit := func(content, message, author string) {
+		testWriteFile(t, r, "file.txt", content)
+		t.Setenv("GIT_AUTHOR_NAME", author)
+		testCommit(t, r, message)
+	}
+	commit("one\ntwo\n", "first", "ann")
+	commit("one\nnew\ntwo\n", "second", "bob")
+	commit("one\nnew\n  two\n", "reindent", "carl")
+
+	lines, err := r.Blame("file.txt", "HEAD", &BlameOptions{IgnoreWhitespace: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, l := range lines {
+		got = append(got, l.Author+": "+l.Content)
+	}
+	expect := []string{"ann: one", "bob: new", "ann:   two"}
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %v\ngot      : %v", expect, got)
+	}
+
+	lines, err = r.Blame("file.txt", "HEAD", &BlameOptions{Ranges: []string{"3,3"}})
+	if err != nil || len(lines) != 1 || lines[0].Author != "carl" || lines[0].Line != 3 {
+		t.Errorf("unexpected blame of line 3: %+v, %v", lines, err)
+	}
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"errors"
+	"strconv"
+)
+
+// CherryPickOptions configures CherryPick.
+type CherryPickOptions struct {
+	Mainline        int      // Mainline is the parent number, starting at 1, to diff merge commits against (--mainline).
+	RecordOrigin    bool     // RecordOrigin appends "(cherry picked from commit ...)" to the messages (-x).
+	NoCommit        bool     // NoCommit applies the changes to the index and working tree without committing (--no-commit).
+	Strategy        string   // Strategy is the merge strategy to use (--strategy).
+	StrategyOptions []string // StrategyOptions are passed to the merge strategy, e.g. "theirs" (--strategy-option).
+}
+
+// RevertOptions configures Revert.
+type RevertOptions struct {
+	Mainline        int      // Mainline is the parent number, starting at 1, to diff merge commits against (--mainline).
+	NoCommit        bool     // NoCommit applies the changes to the index and working tree without committing (--no-commit).
+	Strategy        string   // Strategy is the merge strategy to use (--strategy).
+	StrategyOptions []string // StrategyOptions are passed to the merge strategy (--strategy-option).
+}
+
+// CherryPick applies the changes introduced by commits, which may include ranges such as
+// "main~3..main", on top of the current branch. opts may be nil.
+// If it stops on conflicts it returns a non-nil Conflict and err is nil;
+// use CherryPickContinue, CherryPickSkip or CherryPickAbort to proceed.
+func (r *Repository) CherryPick(opts *CherryPickOptions, commits ...string) (*Conflict, error) {
+	if len(commits) == 0 {
+		return nil, errors.New("go-git: CherryPick() no commits specified")
+	}
+	if opts == nil {
+		opts = &CherryPickOptions{}
+	}
+	args := []string{"cherry-pick"}
+	if opts.RecordOrigin {
+		args = append(args, "-x")
+	}
+	args = append(args, pickArgs(opts.Mainline, opts.NoCommit, opts.Strategy, opts.StrategyOptions)...)
+	args = append(args, commits...)
+	return r.sequence(r.command(args...), "CHERRY_PICK_HEAD")
+}
+
+// CherryPickContinue continues a stopped cherry-pick once the conflicts have been resolved and staged.
+func (r *Repository) CherryPickContinue() (*Conflict, error) {
+	return r.sequence(r.command("cherry-pick", "--continue"), "CHERRY_PICK_HEAD")
+}
+
+// CherryPickSkip continues a stopped cherry-pick, dropping the commit that could not be applied.
+func (r *Repository) CherryPickSkip() (*Conflict, error) {
+	return r.sequence(r.command("cherry-pick", "--skip"), "CHERRY_PICK_HEAD")
+}
+
+// CherryPickAbort abandons a stopped cherry-pick and restores the branch to its original state.
+func (r *Repository) CherryPickAbort() error {
+	_, _, err := r.run("cherry-pick", "--abort")
+	return err
+}
+
+// Revert creates commits reverting the changes introduced by commits, which may include
+// ranges such as "main~3..main". opts may be nil.
+// If it stops on conflicts it returns a non-nil Conflict and err is nil;
+// use RevertContinue, RevertSkip or RevertAbort to proceed.
+func (r *Repository) Revert(opts *RevertOptions, commits ...string) (*Conflict, error) {
+	if len(commits) == 0 {
+		return nil, errors.New("go-git: Revert() no commits specified")
+	}
+	if opts == nil {
+		opts = &RevertOptions{}
+	}
+	args := []string{"revert"}
+	args = append(args, pickArgs(opts.Mainline, opts.NoCommit, opts.Strategy, opts.StrategyOptions)...)
+	args = append(args, commits...)
+	return r.sequence(r.command(args...), "REVERT_HEAD")
+}
+
+// RevertContinue continues a stopped revert once the conflicts have been resolved and staged.
+func (r *Repository) RevertContinue() (*Conflict, error) {
+	return r.sequence(r.command("revert", "--continue"), "REVERT_HEAD")
+}
+
+// RevertSkip continues a stopped revert, dropping the commit that could not be reverted.
+func (r *Repository) RevertSkip() (*Conflict, error) {
+	return r.sequence(r.command("revert", "--skip"), "REVERT_HEAD")
+}
+
+// RevertAbort abandons a stopped revert and restores the branch to its original state.
+func (r *Repository) RevertAbort() error {
+	_, _, err := r.run("revert", "--abort")
+	return err
+}
+
+// pickArgs builds the options cherry-pick and revert have in common.
+func pickArgs(mainline int, noCommit bool, strategy string, strategyOptions []string) []string {
+	args := []string{}
+	if mainline > 0 {
+		args = append(args, "--mainline", strconv.Itoa(mainline))
+	}
+	if noCommit {
+		args = append(args, "--no-commit")
+	}
+	if strategy != "" {
+		args = append(args, "--strategy="+strategy)
+	}
+	for _, opt := range strategyOptions {
+		args = append(args, "--strategy-option="+opt)
+	}
+	return args
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCherryPick(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Opts       *CherryPickOptions
+		Commits    []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No commits specified",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: CherryPick() no commits specified"),
+		},
+		{
+			CaseName:   "Cherry-pick a range",
+			Commits:    []string{"main~3..main"},
+			ExpectArgs: []string{"cherry-pick", "main~3..main"},
+		},
+		{
+			CaseName:   "All options",
+			Opts:       &CherryPickOptions{Mainline: 1, RecordOrigin: true, NoCommit: true, Strategy: "ort", StrategyOptions: []string{"theirs", "renormalize"}},
+			Commits:    []string{"abc123", "def456"},
+			ExpectArgs: []string{"cherry-pick", "-x", "--mainline", "1", "--no-commit", "--strategy=ort", "--strategy-option=theirs", "--strategy-option=renormalize", "abc123", "def456"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := (&Repository{}).CherryPick(c.Opts, c.Commits...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestRevert(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Opts       *RevertOptions
+		Commits    []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No commits specified",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Revert() no commits specified"),
+		},
+		{
+			CaseName:   "Revert a merge without committing",
+			Opts:       &RevertOptions{Mainline: 2, NoCommit: true},
+			Commits:    []string{"abc123"},
+			ExpectArgs: []string{"revert", "--mainline", "2", "--no-commit", "abc123"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := (&Repository{}).Revert(c.Opts, c.Commits...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestCherryPickAndRevertControl(t *testing.T) {
+	gotArgs := [][]string{}
+	execCommand = func(args ...string) runner {
+		gotArgs = append(gotArgs, args)
+		return &mockRunner{}
+	}
+	r := &Repository{}
+	r.CherryPickContinue()
+	r.CherryPickSkip()
+	r.CherryPickAbort()
+	r.RevertContinue()
+	r.RevertSkip()
+	r.RevertAbort()
+	expect := [][]string{
+		{"cherry-pick", "--continue"}, {"cherry-pick", "--skip"}, {"cherry-pick", "--abort"},
+		{"revert", "--continue"}, {"revert", "--skip"}, {"revert", "--abort"},
+	}
+	if !reflect.DeepEqual(expect, gotArgs) {
+		t.Errorf("expected : %v\ngot      : %v", expect, gotArgs)
+	}
+}
@@ -0,0 +1,229 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// File modes of tree entries.
+const (
+	ModeRegular    = "100644"
+	ModeExecutable = "100755"
+	ModeSymlink    = "120000"
+	ModeTree       = "040000"
+	ModeSubmodule  = "160000"
+)
+
+// Signature identifies the author or committer of a commit.
+type Signature struct {
+	Name  string
+	Email string
+	When  time.Time // When defaults to the current time if zero.
+}
+
+// CommitOptions configures CommitBuilder.Commit.
+type CommitOptions struct {
+	Author    *Signature // Author defaults to the configured user.
+	Committer *Signature // Committer defaults to the configured user.
+	// Branch, if set, is moved to the new commit, provided it still points at the
+	// parent, or does not exist yet for a root commit.
+	Branch string
+}
+
+type fileChange struct {
+	path    string
+	content []byte
+	mode    string
+	remove  bool
+	chmod   bool
+}
+
+// CommitBuilder creates a commit from a parent revision and a set of file changes without
+// using the working tree or the index, so it also works in bare repositories.
+type CommitBuilder struct {
+	repo    *Repository
+	parent  string
+	changes []fileChange
+}
+
+// CommitBuilder starts building a commit on top of parent. If parent is empty a root commit is built.
+func (r *Repository) CommitBuilder(parent string) *CommitBuilder {
+	return &CommitBuilder{repo: r, parent: parent}
+}
+
+// WriteFile sets the content and mode, e.g. ModeRegular, of the file at path.
+// An empty mode means ModeRegular.
+// path is relative to the root of the tree and uses forward slashes.
+func (b *CommitBuilder) WriteFile(path string, content []byte, mode string) {
+	if mode == "" {
+		mode = ModeRegular
+	}
+	b.changes = append(b.changes, fileChange{path: path, content: content, mode: mode})
+}
+
+// Delete removes the file at path, or every file below path if it is a directory.
+// path is relative to the root of the tree, like for WriteFile.
+// Commit fails if nothing matches path.
+func (b *CommitBuilder) Delete(path string) {
+	b.changes = append(b.changes, fileChange{path: path, remove: true})
+}
+
+// SetExecutable changes whether the file at path is executable without changing its content.
+func (b *CommitBuilder) SetExecutable(path string, executable bool) {
+	mode := ModeRegular
+	if executable {
+		mode = ModeExecutable
+	}
+	b.changes = append(b.changes, fileChange{path: path, mode: mode, chmod: true})
+}
+
+// Commit writes the tree and commit and returns the hash of the new commit.
+// opts may be nil.
+func (b *CommitBuilder) Commit(message string, opts *CommitOptions) (string, error) {
+	if opts == nil {
+		opts = &CommitOptions{}
+	}
+	r := b.repo
+	parent := ""
+	if b.parent != "" {
+		stdout, _, err := r.run("rev-parse", "--verify", "--quiet", b.parent+"^{commit}")
+		if err != nil {
+			return "", errors.New("go-git: CommitBuilder.Commit() unknown parent " + b.parent)
+		}
+		parent = strings.TrimSpace(stdout)
+	}
+
+	dir, err := os.MkdirTemp("", "go-git-index-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	indexEnv := "GIT_INDEX_FILE=" + filepath.Join(dir, "index")
+	index := func(stdin string, args ...string) (string, error) {
+		c := r.command(args...)
+		c.env = append(c.env, indexEnv)
+		if stdin != "" {
+			c.stdin = strings.NewReader(stdin)
+		}
+		stdout, _, err := c.run()
+		return stdout, err
+	}
+
+	if parent != "" {
+		_, err = index("", "read-tree", parent)
+	} else {
+		_, err = index("", "read-tree", "--empty")
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, change := range b.changes {
+		switch {
+		case change.remove:
+			// A zero mode removes an entry; update-index --force-remove needs a work tree.
+			var listed, format string
+			if listed, err = index("", "ls-files", "-z", "--full-name", "--", ":(top,literal)"+change.path); err != nil {
+				break
+			}
+			paths := splitNul(listed)
+			if len(paths) == 0 {
+				return "", errors.New("go-git: CommitBuilder.Commit() unknown path " + change.path)
+			}
+			if format, _, err = r.run("rev-parse", "--show-object-format"); err != nil {
+				break
+			}
+			zero := strings.Repeat("0", 40)
+			if strings.TrimSpace(format) == "sha256" {
+				zero = strings.Repeat("0", 64)
+			}
+			var info strings.Builder
+			for _, path := range paths {
+				info.WriteString("0 " + zero + "\t" + path + "\x00")
+			}
+			_, err = index(info.String(), "update-index", "-z", "--index-info")
+		case change.chmod:
+			// update-index --chmod needs a work tree, so re-add the staged blob with the new mode.
+			var hash string
+			if hash, err = index("", "rev-parse", "--verify", "--quiet", ":"+change.path); err != nil {
+				return "", errors.New("go-git: CommitBuilder.Commit() unknown path " + change.path)
+			}
+			_, err = index(change.mode+" "+strings.TrimSpace(hash)+"\t"+change.path+"\x00", "update-index", "-z", "--index-info")
+		default:
+			c := r.command("hash-object", "-w", "--stdin")
+			c.stdin = strings.NewReader(string(change.content))
+			var hash string
+			if hash, _, err = c.run(); err != nil {
+				break
+			}
+			info := change.mode + " " + strings.TrimSpace(hash) + "\t" + change.path + "\x00"
+			_, err = index(info, "update-index", "--add", "-z", "--index-info")
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	tree, err := index("", "write-tree")
+	if err != nil {
+		return "", err
+	}
+	tree = strings.TrimSpace(tree)
+
+	args := []string{"commit-tree", tree}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+	c := r.command(append(args, "-F", "-")...)
+	c.stdin = strings.NewReader(message)
+	c.env = append(c.env, signatureEnv("AUTHOR", opts.Author)...)
+	c.env = append(c.env, signatureEnv("COMMITTER", opts.Committer)...)
+	stdout, _, err := c.run()
+	if err != nil {
+		return "", err
+	}
+	commit := strings.TrimSpace(stdout)
+
+	if opts.Branch != "" {
+		ref := opts.Branch
+		if !strings.HasPrefix(ref, "refs/") {
+			ref = "refs/heads/" + ref
+		}
+		tx := r.RefTransaction()
+		if parent != "" {
+			tx.Update(ref, commit, parent)
+		} else {
+			tx.Create(ref, commit)
+		}
+		if err := tx.Commit("commit: " + firstLine(message)); err != nil {
+			return commit, err
+		}
+	}
+	return commit, nil
+}
+
+// signatureEnv returns the environment setting the author or committer, selected by role, to s.
+func signatureEnv(role string, s *Signature) []string {
+	if s == nil {
+		return nil
+	}
+	env := []string{}
+	if s.Name != "" {
+		env = append(env, "GIT_"+role+"_NAME="+s.Name)
+	}
+	if s.Email != "" {
+		env = append(env, "GIT_"+role+"_EMAIL="+s.Email)
+	}
+	if !s.When.IsZero() {
+		env = append(env, "GIT_"+role+"_DATE="+strconv.FormatInt(s.When.Unix(), 10)+" "+s.When.Format("-0700"))
+	}
+	return env
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitBuilder(t *testing.T) {
+	r := testBareRepository(t)
+	author := &Signature{Name: "go-git", Email: "go-git@example.com", When: time.Unix(1700000000, 0).UTC()}
+	opts := &CommitOptions{Author: author, Committer: author, Branch: "main"}
+
+	b := r.CommitBuilder("")
+	b.WriteFile("README.md", []byte("hello\n"), ModeRegular)
+	b.WriteFile("bin/run.sh", []byte("#!/bin/sh\n"), ModeRegular)
+	b.WriteFile("old.txt", []byte("old\n"), ModeRegular)
+	first, err := b.Commit("first", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b = r.CommitBuilder("main")
+	b.Delete("old.txt")
+	b.SetExecutable("bin/run.sh", true)
+	b.WriteFile("link", []byte("README.md"), ModeSymlink)
+	second, err := b.Commit("second\n\nbody", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, _ := r.run("ls-tree", "-r", "--format=%(objectmode) %(path)", "main")
+	expect := "100644 README.md\n100755 bin/run.sh\n120000 link\n"
+	if stdout != expect {
+		t.Errorf("expected : %q\ngot      : %q", expect, stdout)
+	}
+	stdout, _, _ = r.run("log", "--format=%P %an %at %s", "main")
+	expect = first + " go-git 1700000000 second\n go-git 1700000000 first\n"
+	if stdout != expect {
+		t.Errorf("expected : %q\ngot      : %q", expect, stdout)
+	}
+
+	// The branch has moved on, so a commit built on first must not overwrite it.
+	b = r.CommitBuilder(first)
+	b.WriteFile("README.md", []byte("stale\n"), ModeRegular)
+	_, err = b.Commit("stale", opts)
+	var txErr *RefTransactionError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expected a RefTransactionError, got %v", err)
+	}
+	stdout, _, _ = r.run("rev-parse", "main")
+	if strings.TrimSpace(stdout) != second {
+		t.Errorf("main moved to %s", stdout)
+	}
+
+	b = r.CommitBuilder("main")
+	b.Delete("bin")
+	b.Delete("bi")
+	third, err := b.Commit("remove bin", &CommitOptions{Author: author, Committer: author})
+	if !equalErr(errors.New("go-git: CommitBuilder.Commit() unknown path bi"), err) {
+		t.Errorf("expected deleting a path that does not exist to fail, got %v, %v", third, err)
+	}
+	b = r.CommitBuilder("main")
+	b.Delete("bin")
+	third, err = b.Commit("remove bin", &CommitOptions{Author: author, Committer: author})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, _ = r.run("ls-tree", "-r", "--name-only", third)
+	if stdout != "README.md\nlink\n" {
+		t.Errorf("expected the bin directory to be deleted, got %q", stdout)
+	}
+
+	_, err = r.CommitBuilder("missing").Commit("", nil)
+	if !equalErr(errors.New("go-git: CommitBuilder.Commit() unknown parent missing"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestCommitBuilderSubdirectory(t *testing.T) {
+	r := testRepository(t, "")
+	testWriteFile(t, r, "sub/a", "a\n")
+	testWriteFile(t, r, "sub/c", "c\n")
+	testWriteFile(t, r, "b", "b\n")
+	testCommit(t, r, "first")
+	author := &Signature{Name: "go-git", Email: "go-git@example.com"}
+
+	// Paths stay relative to the root of the tree whatever directory the handle is in.
+	sub := &Repository{Dir: filepath.Join(r.Dir, "sub")}
+	b := sub.CommitBuilder("main")
+	b.Delete("sub/a")
+	b.SetExecutable("b", true)
+	b.WriteFile("sub/new", []byte("new\n"), "")
+	commit, err := b.Commit("second", &CommitOptions{Author: author, Committer: author})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, _ := r.run("ls-tree", "-r", "--format=%(objectmode) %(path)", commit)
+	expect := "100755 b\n100644 sub/c\n100644 sub/new\n"
+	if stdout != expect {
+		t.Errorf("expected : %q\ngot      : %q", expect, stdout)
+	}
+}
@@ -0,0 +1,236 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConflictKind describes how the two sides of a conflicted path differ.
+type ConflictKind int
+
+const (
+	// BothModified means both sides changed the path.
+	BothModified ConflictKind = iota
+	// BothAdded means both sides added the path with different contents.
+	BothAdded
+	// DeletedByUs means our side deleted the path and their side changed it.
+	DeletedByUs
+	// DeletedByThem means their side deleted the path and our side changed it.
+	DeletedByThem
+	// AddedByUs means only our side has the path, usually because of a rename.
+	AddedByUs
+	// AddedByThem means only their side has the path, usually because of a rename.
+	AddedByThem
+	// BothDeleted means both sides deleted the path, usually because of diverging renames.
+	BothDeleted
+)
+
+// IndexStage is one side of a conflicted path as recorded in the index.
+type IndexStage struct {
+	Mode string
+	Hash string
+}
+
+// ConflictedFile is a path with unresolved conflicts. Sides missing from the conflict are nil.
+// Paths, here and in the methods resolving conflicts, are relative to the repository's Dir.
+// Note that during a rebase "ours" is the branch being rebased onto and "theirs" the commit being applied.
+type ConflictedFile struct {
+	Path   string
+	Kind   ConflictKind
+	Base   *IndexStage // Base is the common ancestor, index stage 1.
+	Ours   *IndexStage // Ours is the current side, index stage 2.
+	Theirs *IndexStage // Theirs is the side being merged in, index stage 3.
+}
+
+// Conflicts lists the paths with unresolved conflicts, sorted by path.
+func (r *Repository) Conflicts() ([]ConflictedFile, error) {
+	stdout, _, err := r.run("ls-files", "--unmerged", "-z")
+	if err != nil {
+		return nil, err
+	}
+	return parseUnmerged(stdout), nil
+}
+
+// parseUnmerged parses the "<mode> <hash> <stage>\t<path>" records of git ls-files --unmerged -z.
+func parseUnmerged(out string) []ConflictedFile {
+	byPath := map[string]*ConflictedFile{}
+	paths := []string{}
+	for _, record := range splitNul(out) {
+		info, path, ok := strings.Cut(record, "\t")
+		fields := strings.Fields(info)
+		if !ok || len(fields) != 3 {
+			continue
+		}
+		f, ok := byPath[path]
+		if !ok {
+			f = &ConflictedFile{Path: path}
+			byPath[path] = f
+			paths = append(paths, path)
+		}
+		stage := &IndexStage{Mode: fields[0], Hash: fields[1]}
+		switch fields[2] {
+		case "1":
+			f.Base = stage
+		case "2":
+			f.Ours = stage
+		case "3":
+			f.Theirs = stage
+		}
+	}
+	sort.Strings(paths)
+	files := make([]ConflictedFile, 0, len(paths))
+	for _, path := range paths {
+		f := byPath[path]
+		f.Kind = conflictKind(f.Base != nil, f.Ours != nil, f.Theirs != nil)
+		files = append(files, *f)
+	}
+	return files
+}
+
+func conflictKind(base, ours, theirs bool) ConflictKind {
+	switch {
+	case ours && theirs && !base:
+		return BothAdded
+	case base && !ours && theirs:
+		return DeletedByUs
+	case base && ours && !theirs:
+		return DeletedByThem
+	case !base && ours && !theirs:
+		return AddedByUs
+	case !base && !ours && theirs:
+		return AddedByThem
+	case base && !ours && !theirs:
+		return BothDeleted
+	}
+	return BothModified
+}
+
+// ConflictContents returns the contents of the base, ours and theirs sides of f.
+// Missing sides are returned as nil.
+func (r *Repository) ConflictContents(f ConflictedFile) (base, ours, theirs []byte, err error) {
+	read := func(s *IndexStage) ([]byte, error) {
+		if s == nil {
+			return nil, nil
+		}
+		stdout, _, err := r.run("cat-file", "blob", s.Hash)
+		return []byte(stdout), err
+	}
+	if base, err = read(f.Base); err != nil {
+		return nil, nil, nil, err
+	}
+	if ours, err = read(f.Ours); err != nil {
+		return nil, nil, nil, err
+	}
+	if theirs, err = read(f.Theirs); err != nil {
+		return nil, nil, nil, err
+	}
+	return base, ours, theirs, nil
+}
+
+// TakeOurs resolves the conflicted paths by keeping our side, deleting paths our side deleted.
+func (r *Repository) TakeOurs(paths ...string) error {
+	return r.take("--ours", func(f ConflictedFile) bool { return f.Ours != nil }, paths)
+}
+
+// TakeTheirs resolves the conflicted paths by keeping their side, deleting paths their side deleted.
+func (r *Repository) TakeTheirs(paths ...string) error {
+	return r.take("--theirs", func(f ConflictedFile) bool { return f.Theirs != nil }, paths)
+}
+
+func (r *Repository) take(side string, present func(ConflictedFile) bool, paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("go-git: no conflicted paths specified")
+	}
+	conflicts, err := r.Conflicts()
+	if err != nil {
+		return err
+	}
+	byPath := map[string]ConflictedFile{}
+	for _, f := range conflicts {
+		byPath[f.Path] = f
+	}
+	for _, path := range paths {
+		f, ok := byPath[path]
+		if !ok {
+			return errors.New("go-git: " + path + " is not conflicted")
+		}
+		if !present(f) {
+			if _, _, err := r.run("rm", "--quiet", "--", path); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, _, err := r.run("checkout", side, "--", path); err != nil {
+			return err
+		}
+		if _, _, err := r.run("add", "--", path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve writes content to the conflicted path and marks it as resolved.
+func (r *Repository) Resolve(path string, content []byte) error {
+	if path == "" {
+		return errors.New("go-git: Resolve() no path specified")
+	}
+	full := filepath.Join(r.Dir, filepath.FromSlash(path))
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(full); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(full, content, mode); err != nil {
+		return err
+	}
+	return r.MarkResolved(path)
+}
+
+// MarkResolved stages the paths as they are in the working tree, marking their conflicts
+// as resolved. Paths missing from the working tree are staged as deleted.
+func (r *Repository) MarkResolved(paths ...string) error {
+	if len(paths) == 0 {
+		return errors.New("go-git: MarkResolved() no paths specified")
+	}
+	args := append([]string{"add", "--all", "--"}, paths...)
+	_, _, err := r.run(args...)
+	return err
+}
+
+// AbortOperation aborts the merge, rebase, cherry-pick, revert or git am in progress,
+// restoring the state from before it started.
+func (r *Repository) AbortOperation() error {
+	state, err := r.State()
+	if err != nil {
+		return err
+	}
+	var args []string
+	switch state.Operation {
+	case OperationRebase, OperationRebaseApply:
+		args = []string{"rebase", "--abort"}
+	case OperationApplyMailbox:
+		args = []string{"am", "--abort"}
+	case OperationCherryPick:
+		args = []string{"cherry-pick", "--abort"}
+	case OperationRevert:
+		args = []string{"revert", "--abort"}
+	case OperationMerge:
+		args = []string{"merge", "--abort"}
+	default:
+		return errors.New("go-git: AbortOperation() no operation in progress")
+	}
+	_, _, err = r.run(args...)
+	return err
+}
+
+// gitDir returns the absolute path of the repository's git directory.
+func (r *Repository) gitDir() (string, error) {
+	stdout, _, err := r.run("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
@@ -0,0 +1,101 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseUnmerged(t *testing.T) {
+	out := "100644 78981922613b2afb6025042ff6bd878ac1994e85 1\tsrc/g\x00" +
+		"100644 587be6b4c3f93f93c489c0111bba5596147a26cb 2\tsrc/g\x00" +
+		"100644 78981922613b2afb6025042ff6bd878ac1994e85 1\tf\x00" +
+		"100644 587be6b4c3f93f93c489c0111bba5596147a26cb 2\tf\x00" +
+		"100755 61780798228d17af2d34fce4cfbdf35556832472 3\tf\x00"
+	expect := []ConflictedFile{
+		{
+			Path:   "f",
+			Kind:   BothModified,
+			Base:   &IndexStage{Mode: "100644", Hash: "78981922613b2afb6025042ff6bd878ac1994e85"},
+			Ours:   &IndexStage{Mode: "100644", Hash: "587be6b4c3f93f93c489c0111bba5596147a26cb"},
+			Theirs: &IndexStage{Mode: "100755", Hash: "61780798228d17af2d34fce4cfbdf35556832472"},
+		},
+		{
+			Path: "src/g",
+			Kind: DeletedByThem,
+			Base: &IndexStage{Mode: "100644", Hash: "78981922613b2afb6025042ff6bd878ac1994e85"},
+			Ours: &IndexStage{Mode: "100644", Hash: "587be6b4c3f93f93c489c0111bba5596147a26cb"},
+		},
+	}
+	if got := parseUnmerged(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
+
+func TestConflictKind(t *testing.T) {
+	cases := []struct {
+		Base, Ours, Theirs bool
+		ExpectKind         ConflictKind
+	}{
+		{true, true, true, BothModified},
+		{false, true, true, BothAdded},
+		{true, false, true, DeletedByUs},
+		{true, true, false, DeletedByThem},
+		{false, true, false, AddedByUs},
+		{false, false, true, AddedByThem},
+		{true, false, false, BothDeleted},
+	}
+	for _, c := range cases {
+		if got := conflictKind(c.Base, c.Ours, c.Theirs); got != c.ExpectKind {
+			t.Errorf("stages %v %v %v\nexpected : %v\ngot      : %v", c.Base, c.Ours, c.Theirs, c.ExpectKind, got)
+		}
+	}
+}
+
+func TestMarkResolved(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Paths      []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No paths specified",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: MarkResolved() no paths specified"),
+		},
+		{
+			CaseName:   "Mark paths resolved",
+			Paths:      []string{"file-1", "file-2"},
+			ExpectArgs: []string{"add", "--all", "--", "file-1", "file-2"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := (&Repository{}).MarkResolved(c.Paths...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestTakeNotConflicted(t *testing.T) {
+	execCommand = func(args ...string) runner {
+		return &mockRunner{}
+	}
+	r := &Repository{}
+	if err := r.TakeOurs(); !equalErr(errors.New("go-git: no conflicted paths specified"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := r.TakeTheirs("file-1"); !equalErr(errors.New("go-git: file-1 is not conflicted"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	neturl "net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CredentialsFunc returns the username and password to authenticate against url with.
+// Returning empty strings leaves authentication to git's own configuration.
+//
+// The credentials are handed to git through its credential helper protocol using
+// environment variables, so they never appear in command lines or in .git/config.
+// They are only offered to the scheme and host of url.
+// The password is scrubbed from the errors of the commands that used it, see Redact.
+type CredentialsFunc func(url string) (username, password string, err error)
+
+// credentialHelper answers git's credential requests from the environment of the git process.
+const credentialHelper = `!f() { test "$1" = get && printf 'username=%s\npassword=%s\n' "$GO_GIT_USERNAME" "$GO_GIT_PASSWORD"; }; f`
+
+// withCredentials asks the repository's Credentials callback for url and arranges for c to use the result.
+func (r *Repository) withCredentials(c *command, url string) error {
+	if r.Credentials == nil {
+		return nil
+	}
+	username, password, err := r.Credentials(url)
+	if err != nil {
+		return err
+	}
+	if username == "" && password == "" {
+		return nil
+	}
+	if password != "" {
+		c.secrets = append(c.secrets, password)
+	}
+	c.env = append(c.env, credentialEnv(url, username, password)...)
+	return nil
+}
+
+// credentialEnv returns the environment that makes git use credentialHelper, and only it,
+// to obtain username and password for the host of url. Other hosts git talks to, such as
+// those of submodules or redirects, are left to git's own configuration.
+func credentialEnv(url, username, password string) []string {
+	key := "credential." + credentialScope(url) + ".helper"
+	return append(configEnv(
+		key, "",
+		key, credentialHelper,
+	), "GO_GIT_USERNAME="+username, "GO_GIT_PASSWORD="+password, "GIT_TERMINAL_PROMPT=0")
+}
+
+// credentialScope returns the scheme and host of rawURL, which git matches credential
+// requests against, or rawURL itself if it has no host.
+func credentialScope(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// configEnv returns the environment that sets the given configuration key value pairs
+// for a single git invocation. The pairs are numbered after any already passed to this
+// process through GIT_CONFIG_COUNT, so that those still apply.
+func configEnv(pairs ...string) []string {
+	base, err := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	if err != nil || base < 0 {
+		base = 0
+	}
+	env := []string{"GIT_CONFIG_COUNT=" + strconv.Itoa(base+len(pairs)/2)}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		n := strconv.Itoa(base + i/2)
+		env = append(env, "GIT_CONFIG_KEY_"+n+"="+pairs[i], "GIT_CONFIG_VALUE_"+n+"="+pairs[i+1])
+	}
+	return env
+}
+
+// remoteURL returns the fetch or push url of remote, or remote itself if it is not
+// the name of a configured remote.
+func (r *Repository) remoteURL(remote string, push bool) string {
+	if r.Credentials == nil {
+		return remote
+	}
+	args := []string{"remote", "get-url"}
+	if push {
+		args = append(args, "--push")
+	}
+	stdout, _, err := r.run(append(args, remote)...)
+	if url := strings.TrimSpace(stdout); err == nil && url != "" {
+		return url
+	}
+	return remote
+}
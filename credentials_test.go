@@ -0,0 +1,117 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCredentialEnv(t *testing.T) {
+	expect := []string{
+		"GIT_CONFIG_COUNT=2",
+		"GIT_CONFIG_KEY_0=credential.https://example.com.helper", "GIT_CONFIG_VALUE_0=",
+		"GIT_CONFIG_KEY_1=credential.https://example.com.helper", "GIT_CONFIG_VALUE_1=" + credentialHelper,
+		"GO_GIT_USERNAME=user", "GO_GIT_PASSWORD=secret", "GIT_TERMINAL_PROMPT=0",
+	}
+	if got := credentialEnv("https://example.com/org/repo.git", "user", "secret"); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %v\ngot      : %v", expect, got)
+	}
+}
+
+func TestCredentialScope(t *testing.T) {
+	cases := map[string]string{
+		"https://example.com/org/repo.git":           "https://example.com",
+		"https://user@example.com:8443/org/repo.git": "https://example.com:8443",
+		"git@example.com:org/repo.git":               "git@example.com:org/repo.git",
+		"../repo.git":                                "../repo.git",
+	}
+	for in, expect := range cases {
+		if got := credentialScope(in); got != expect {
+			t.Errorf("%s\nexpected : %v\ngot      : %v", in, expect, got)
+		}
+	}
+}
+
+func TestCredentialEnvOtherHost(t *testing.T) {
+	r := testRepository(t, "")
+	fill := func(host string) (string, error) {
+		c := r.command("credential", "fill")
+		c.env = credentialEnv("https://example.com/org/repo.git", "user", "secret")
+		c.stdin = strings.NewReader("protocol=https\nhost=" + host + "\n")
+		stdout, _, err := c.run()
+		return stdout, err
+	}
+	if stdout, err := fill("example.com"); err != nil || !strings.Contains(stdout, "username=user\npassword=secret\n") {
+		t.Errorf("expected the credentials for example.com, got %q, %v", stdout, err)
+	}
+	if stdout, err := fill("other.example.com"); err == nil || strings.Contains(stdout, "secret") {
+		t.Errorf("expected no credentials for another host, got %q, %v", stdout, err)
+	}
+}
+
+func TestCredentialEnvKeepsConfig(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "user.name")
+	t.Setenv("GIT_CONFIG_VALUE_0", "ci")
+	expect := []string{
+		"GIT_CONFIG_COUNT=3",
+		"GIT_CONFIG_KEY_1=credential.https://example.com.helper", "GIT_CONFIG_VALUE_1=",
+		"GIT_CONFIG_KEY_2=credential.https://example.com.helper", "GIT_CONFIG_VALUE_2=" + credentialHelper,
+		"GO_GIT_USERNAME=user", "GO_GIT_PASSWORD=secret", "GIT_TERMINAL_PROMPT=0",
+	}
+	env := credentialEnv("https://example.com/repo.git", "user", "secret")
+	if !reflect.DeepEqual(expect, env) {
+		t.Errorf("expected : %v\ngot      : %v", expect, env)
+	}
+
+	c := testRepository(t, "").command("config", "user.name")
+	c.env = env
+	if stdout, _, err := c.run(); err != nil || stdout != "ci\n" {
+		t.Errorf("expected the configuration from the environment to apply, got %q, %v", stdout, err)
+	}
+}
+
+func TestWithCredentials(t *testing.T) {
+	cases := []struct {
+		CaseName  string
+		Username  string
+		Password  string
+		Err       error
+		ExpectEnv []string
+		ExpectErr error
+	}{
+		{
+			CaseName:  "Credentials supplied",
+			Username:  "user",
+			Password:  "secret",
+			ExpectEnv: credentialEnv("https://example.com/repo.git", "user", "secret"),
+		},
+		{
+			CaseName:  "No credentials supplied",
+			ExpectEnv: nil,
+		},
+		{
+			CaseName:  "Callback fails",
+			Err:       errors.New("no token"),
+			ExpectEnv: nil,
+			ExpectErr: errors.New("no token"),
+		},
+	}
+	for _, c := range cases {
+		gotURL := ""
+		r := &Repository{Credentials: func(url string) (string, string, error) {
+			gotURL = url
+			return c.Username, c.Password, c.Err
+		}}
+		cmd := r.command("clone", "https://example.com/repo.git")
+		gotErr := r.withCredentials(cmd, "https://example.com/repo.git")
+		if gotURL != "https://example.com/repo.git" || !reflect.DeepEqual(c.ExpectEnv, cmd.env) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v, %v",
+				c.CaseName,
+				c.ExpectEnv, c.ExpectErr,
+				gotURL, cmd.env, gotErr,
+			)
+		}
+	}
+}
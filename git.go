@@ -1,8 +1,14 @@
 package git
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 )
 
 type runner interface {
@@ -13,44 +19,346 @@ var (
 	execCommand func(...string) runner = func(args ...string) runner { return exec.Command("git", args...) }
 )
 
-// Init initializes a repository in dir, using the specified template.
-func Init(dir, template string) error {
+// Error is returned when git exits unsuccessfully. Args and Stderr are redacted, see Redact.
+type Error struct {
+	Args   []string // Args are the arguments git was invoked with.
+	Stderr string   // Stderr is what git wrote to standard error.
+	Err    error    // Err is the underlying error, usually an *exec.ExitError.
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("go-git: git %s: %v", strings.Join(e.Args, " "), e.Err)
+	if stderr := strings.TrimSpace(e.Stderr); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Repository is a handle on a git repository. Commands run through a Repository
+// run in Dir, or in the present working directory if Dir is empty.
+type Repository struct {
+	Dir string
+
+	// Progress, if set, receives progress reported by Clone, Fetch, Pull and Push.
+	Progress func(Progress)
+
+	// Credentials, if set, supplies the username and password for the remote url
+	// used by Clone, Fetch, Pull, Push and LsRemote. See CredentialsFunc.
+	Credentials CredentialsFunc
+
+	// SSH, if set, configures how Clone, Fetch, Pull, Push and LsRemote connect to SSH remotes.
+	SSH *SSHOptions
+}
+
+// Open returns a handle on the repository containing dir.
+func Open(dir string) (*Repository, error) {
+	r := &Repository{Dir: dir}
+	if _, _, err := r.run("rev-parse", "--git-dir"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// command is a single git invocation prepared by Repository.command.
+type command struct {
+	args     []string
+	dir      string
+	env      []string
+	stdin    io.Reader
+	progress func(Progress)
+	secrets  []string
+}
+
+// command prepares git to run with args in the repository.
+func (r *Repository) command(args ...string) *command {
+	return &command{args: args, dir: r.Dir}
+}
+
+// run runs git with args in the repository and returns what it wrote to standard output and standard error.
+func (r *Repository) run(args ...string) (stdout, stderr string, err error) {
+	return r.command(args...).run()
+}
+
+// run runs c and returns what git wrote to standard output and standard error.
+func (c *command) run() (stdout, stderr string, err error) {
+	var outBuf, errBuf bytes.Buffer
+	if err := c.prepare(&outBuf, &errBuf).Run(); err != nil {
+		return outBuf.String(), errBuf.String(), c.wrapErr(err, errBuf.String())
+	}
+	return outBuf.String(), errBuf.String(), nil
+}
+
+// start starts c and returns a reader of what git writes to standard output, which must be closed.
+// If git fails, reading returns its *Error once the output is exhausted.
+func (c *command) start() (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	var errBuf bytes.Buffer
+	cmd := c.prepare(pw, &errBuf)
+	wait := cmd.Run
+	if ec, ok := cmd.(*exec.Cmd); ok {
+		if err := ec.Start(); err != nil {
+			return nil, c.wrapErr(err, "")
+		}
+		wait = ec.Wait
+	}
+	s := &stream{PipeReader: pr, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		if err := wait(); err != nil {
+			pw.CloseWithError(c.wrapErr(err, errBuf.String()))
+			return
+		}
+		pw.Close()
+	}()
+	return s, nil
+}
+
+// prepare creates the git process for c writing to stdout and stderr.
+// Messages are forced into the C locale so that they can be parsed.
+func (c *command) prepare(stdout, stderr io.Writer) runner {
+	cmd := execCommand(c.args...)
+	if ec, ok := cmd.(*exec.Cmd); ok {
+		ec.Dir = c.dir
+		ec.Env = append(append(os.Environ(), "LC_ALL=C"), c.env...)
+		ec.Stdin = c.stdin
+		ec.Stdout = stdout
+		ec.Stderr = stderr
+		if c.progress != nil {
+			ec.Stderr = io.MultiWriter(stderr, &progressWriter{fn: c.progress})
+		}
+	}
+	return cmd
+}
+
+// wrapErr returns the redacted *Error for c failing with err after writing stderr.
+func (c *command) wrapErr(err error, stderr string) error {
+	return &Error{Args: redactArgs(c.args, c.secrets), Stderr: redactWith(stderr, c.secrets), Err: err}
+}
+
+// stream is the output of a command started by command.start.
+type stream struct {
+	*io.PipeReader
+	done chan struct{}
+}
+
+// Close stops reading, which makes git exit early if the output was not read to the end,
+// and waits for git to exit.
+func (s *stream) Close() error {
+	s.PipeReader.Close()
+	<-s.done
+	return nil
+}
+
+// withTransport applies the repository's credentials for url and its SSH options to c.
+// The returned function releases anything they needed and must be called once c has run.
+func (r *Repository) withTransport(c *command, url string) (func(), error) {
+	if err := r.withCredentials(c, url); err != nil {
+		return nil, err
+	}
+	return r.withSSH(c)
+}
+
+// withProgress asks git to report progress to c when the repository has a Progress callback.
+func (r *Repository) withProgress(c *command) *command {
+	if r.Progress != nil {
+		args := []string{c.args[0], "--progress"}
+		c.args = append(args, c.args[1:]...)
+		c.progress = r.Progress
+	}
+	return c
+}
+
+// exitStatus returns the exit status of the git command that failed with err, or -1 if it did not exit.
+func exitStatus(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// conflictedFiles lists the paths with unresolved conflicts.
+func (r *Repository) conflictedFiles() ([]string, error) {
+	stdout, _, err := r.run("diff", "--name-only", "-z", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	return splitNul(stdout), nil
+}
+
+// splitNul splits NUL terminated output into its fields.
+func splitNul(out string) []string {
+	fields := []string{}
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// InitOptions configures Init. The zero value behaves like a plain git init.
+type InitOptions struct {
+	Bare           bool   // Bare creates a bare repository (--bare).
+	InitialBranch  string // InitialBranch names the unborn initial branch (--initial-branch).
+	ObjectFormat   string // ObjectFormat selects the hash algorithm, "sha1" or "sha256" (--object-format).
+	Shared         string // Shared makes the repository group or world writable, e.g. "group", "all" or "0660" (--shared).
+	SeparateGitDir string // SeparateGitDir places the git directory elsewhere and links to it from dir (--separate-git-dir).
+	Template       string // Template is the directory templates are copied from (--template).
+}
+
+// Init initializes a repository in dir and returns a handle on it.
+// If dir is not provided the repository is initialized in the present working directory.
+// opts may be nil.
+func Init(dir string, opts *InitOptions) (*Repository, error) {
+	if opts == nil {
+		opts = &InitOptions{}
+	}
+	switch opts.ObjectFormat {
+	case "", "sha1", "sha256":
+	default:
+		return nil, errors.New("go-git: Init() unsupported object format " + opts.ObjectFormat)
+	}
 	args := []string{"init"}
-	if template != "" {
-		args = append(args, "--template='"+template+"'")
+	if opts.Bare {
+		args = append(args, "--bare")
+	}
+	if opts.InitialBranch != "" {
+		args = append(args, "--initial-branch="+opts.InitialBranch)
+	}
+	if opts.ObjectFormat != "" {
+		args = append(args, "--object-format="+opts.ObjectFormat)
+	}
+	if opts.Shared != "" {
+		args = append(args, "--shared="+opts.Shared)
+	}
+	if opts.SeparateGitDir != "" {
+		args = append(args, "--separate-git-dir="+opts.SeparateGitDir)
+	}
+	if opts.Template != "" {
+		args = append(args, "--template="+opts.Template)
 	}
 	if dir != "" {
 		args = append(args, dir)
 	}
-	return execCommand(args...).Run()
+	if _, _, err := (&Repository{}).run(args...); err != nil {
+		return nil, err
+	}
+	return &Repository{Dir: dir}, nil
+}
+
+// CloneOptions configures Clone. The zero value behaves like a plain git clone.
+type CloneOptions struct {
+	Branch            string   // Branch checks out the named branch instead of the remote's HEAD (--branch).
+	Depth             int      // Depth truncates history to the given number of commits when non-zero (--depth).
+	SingleBranch      bool     // SingleBranch only fetches the history of a single branch (--single-branch).
+	Bare              bool     // Bare creates a bare repository (--bare).
+	Mirror            bool     // Mirror creates a bare repository mapping all remote refs (--mirror).
+	Filter            string   // Filter requests a partial clone, e.g. "blob:none" (--filter).
+	Reference         []string // Reference borrows objects from local repositories (--reference).
+	Dissociate        bool     // Dissociate copies borrowed objects so the clone stops depending on Reference (--dissociate).
+	RecurseSubmodules bool     // RecurseSubmodules initializes and clones submodules (--recurse-submodules).
+	NoCheckout        bool     // NoCheckout skips checking out HEAD after the clone (--no-checkout).
+	Sparse            bool     // Sparse starts with a sparse checkout of the top level files only (--sparse).
 }
 
 // Clone clones the specified repository into dir.
 // If dir is not provided the specified repository is cloned into the present working directory.
-func Clone(repo, dir string) error {
+// opts may be nil.
+func Clone(repo, dir string, opts *CloneOptions) error {
+	return (&Repository{Dir: dir}).Clone(repo, opts)
+}
+
+// Clone clones the specified repository into the repository's Dir.
+// If Dir is empty the repository is cloned into the present working directory.
+// opts may be nil.
+func (r *Repository) Clone(repo string, opts *CloneOptions) error {
 	if repo == "" {
 		return errors.New("go-git: Clone() no repository specified")
 	}
-	args := []string{"clone", repo}
-	if dir != "" {
-		args = append(args, dir)
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+	if opts.Depth < 0 {
+		return errors.New("go-git: Clone() depth must not be negative")
+	}
+	args := []string{"clone"}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.SingleBranch {
+		args = append(args, "--single-branch")
 	}
-	return execCommand(args...).Run()
+	if opts.Bare {
+		args = append(args, "--bare")
+	}
+	if opts.Mirror {
+		args = append(args, "--mirror")
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter="+opts.Filter)
+	}
+	for _, ref := range opts.Reference {
+		args = append(args, "--reference", ref)
+	}
+	if opts.Dissociate {
+		args = append(args, "--dissociate")
+	}
+	if opts.RecurseSubmodules {
+		args = append(args, "--recurse-submodules")
+	}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
+	if opts.Sparse {
+		args = append(args, "--sparse")
+	}
+	args = append(args, repo)
+	if r.Dir != "" {
+		args = append(args, r.Dir)
+	}
+	c := r.withProgress(r.command(args...))
+	c.dir = ""
+	cleanup, err := r.withTransport(c, repo)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	_, _, err = c.run()
+	return err
 }
 
-// Add adds the specified files to the working tree. If no files are provided all files will be added.
+// Add calls Repository.Add on the repository in the present working directory.
 func Add(files ...string) error {
+	return (&Repository{}).Add(files...)
+}
+
+// Add adds the specified files to the working tree. If no files are provided all files will be added.
+func (r *Repository) Add(files ...string) error {
 	args := []string{"add"}
 	if len(files) == 0 {
 		args = append(args, ".")
 	} else {
 		args = append(args, files...)
 	}
-	return execCommand(args...).Run()
+	_, _, err := r.run(args...)
+	return err
 }
 
-// Remove removes the specified file from the working tree. If no files are provided all files will be removed.
+// Remove calls Repository.Remove on the repository in the present working directory.
 func Remove(recursive bool, files ...string) error {
+	return (&Repository{}).Remove(recursive, files...)
+}
+
+// Remove removes the specified file from the working tree. If no files are provided all files will be removed.
+func (r *Repository) Remove(recursive bool, files ...string) error {
 	args := []string{"rm"}
 	if len(files) == 0 && !recursive {
 		return errors.New("go-git: Remove() called without specifying files or recursive")
@@ -59,46 +367,76 @@ func Remove(recursive bool, files ...string) error {
 	} else {
 		args = append(args, files...)
 	}
-	return execCommand(args...).Run()
+	_, _, err := r.run(args...)
+	return err
 }
 
-// Commit commits all changes from the working tree to the index.
+// Commit calls Repository.Commit on the repository in the present working directory.
 func Commit(msg string) error {
+	return (&Repository{}).Commit(msg)
+}
+
+// Commit commits all changes from the working tree to the index.
+func (r *Repository) Commit(msg string) error {
 	args := []string{"commit"}
 	if msg != "" {
 		args = append(args, "--message='"+msg+"'")
 	} else {
 		args = append(args, []string{"--allow-empty-message", "--message=''"}...)
 	}
-	return execCommand(args...).Run()
+	_, _, err := r.run(args...)
+	return err
 }
 
-// Branch creates a new branch.
+// Branch calls Repository.Branch on the repository in the present working directory.
 func Branch(name string) error {
+	return (&Repository{}).Branch(name)
+}
+
+// Branch creates a new branch.
+func (r *Repository) Branch(name string) error {
 	if name == "" {
 		return errors.New("go-git: Branch() no branch name specified")
 	}
-	return execCommand("branch", name).Run()
+	_, _, err := r.run("branch", name)
+	return err
 }
 
-// DeleteBranch deletes an existing branch.
+// DeleteBranch calls Repository.DeleteBranch on the repository in the present working directory.
 func DeleteBranch(name string) error {
+	return (&Repository{}).DeleteBranch(name)
+}
+
+// DeleteBranch deletes an existing branch.
+func (r *Repository) DeleteBranch(name string) error {
 	if name == "" {
 		return errors.New("go-git: DeleteBranch() no branch name specified")
 	}
-	return execCommand("branch", "-d", name).Run()
+	_, _, err := r.run("branch", "-d", name)
+	return err
 }
 
-// Checkout checks out a branch.
+// Checkout calls Repository.Checkout on the repository in the present working directory.
 func Checkout(branch string) error {
+	return (&Repository{}).Checkout(branch)
+}
+
+// Checkout checks out a branch.
+func (r *Repository) Checkout(branch string) error {
 	if branch == "" {
 		return errors.New("go-git: Checkout() no branch name specified")
 	}
-	return execCommand("checkout", branch).Run()
+	_, _, err := r.run("checkout", branch)
+	return err
 }
 
-// Tag creates a new tag with the provided name and message
+// Tag calls Repository.Tag on the repository in the present working directory.
 func Tag(name, msg string) error {
+	return (&Repository{}).Tag(name, msg)
+}
+
+// Tag creates a new tag with the provided name and message
+func (r *Repository) Tag(name, msg string) error {
 	if name == "" {
 		return errors.New("go-git: Tag() no tag name specified")
 	}
@@ -109,80 +447,377 @@ func Tag(name, msg string) error {
 		args = append(args, "-a")
 	}
 	args = append(args, name)
-	return execCommand(args...).Run()
+	_, _, err := r.run(args...)
+	return err
 }
 
-// DeleteTag deletes the named tag.
+// DeleteTag calls Repository.DeleteTag on the repository in the present working directory.
 func DeleteTag(name string) error {
+	return (&Repository{}).DeleteTag(name)
+}
+
+// DeleteTag deletes the named tag.
+func (r *Repository) DeleteTag(name string) error {
 	if name == "" {
 		return errors.New("go-git: DeleteTag() no tag name specified")
 	}
-	return execCommand("tag", "-d", name).Run()
+	_, _, err := r.run("tag", "-d", name)
+	return err
 }
 
-// Merge Merges branch with the current branch.
-func Merge(branch, msg string, fastforward bool) error {
-	if branch == "" {
-		return errors.New("go-git: Merge() called without specifying a branch")
+// MergeOptions configures Merge. The zero value behaves like a plain git merge.
+type MergeOptions struct {
+	Message                 string   // Message is the message of the merge commit. The default is generated by git (--message).
+	NoFastForward           bool     // NoFastForward creates a merge commit even when a fast-forward is possible (--no-ff).
+	FastForwardOnly         bool     // FastForwardOnly refuses to merge unless the result is a fast-forward (--ff-only).
+	Squash                  bool     // Squash stages the merged changes without committing or recording a merge (--squash).
+	NoCommit                bool     // NoCommit stops before creating the merge commit (--no-commit).
+	Strategy                string   // Strategy is the merge strategy, e.g. "ort", "ours" or "octopus" (--strategy).
+	StrategyOptions         []string // StrategyOptions are passed to the strategy, e.g. "ours", "theirs" or "renormalize" (--strategy-option).
+	AllowUnrelatedHistories bool     // AllowUnrelatedHistories merges histories without a common ancestor (--allow-unrelated-histories).
+}
+
+// MergeOutcome describes what Merge did to the current branch.
+type MergeOutcome int
+
+const (
+	// MergeUpToDate means there was nothing to merge.
+	MergeUpToDate MergeOutcome = iota
+	// MergeFastForward means the current branch was fast-forwarded.
+	MergeFastForward
+	// MergeCommitted means a merge commit was created.
+	MergeCommitted
+	// MergeUncommitted means the merge succeeded but, as requested by Squash or NoCommit, was not committed.
+	MergeUncommitted
+	// MergeConflict means the merge stopped with conflicts that must be resolved.
+	MergeConflict
+)
+
+// MergeResult is the result of Merge.
+type MergeResult struct {
+	Outcome   MergeOutcome
+	Conflicts []string // Conflicts are the conflicted paths when Outcome is MergeConflict.
+}
+
+// Merge calls Repository.Merge on the repository in the present working directory.
+func Merge(opts *MergeOptions, heads ...string) (*MergeResult, error) {
+	return (&Repository{}).Merge(opts, heads...)
+}
+
+// Merge merges heads into the current branch. Merging more than one head creates an octopus merge.
+// opts may be nil. A merge that stops on conflicts returns MergeConflict and no error.
+func (r *Repository) Merge(opts *MergeOptions, heads ...string) (*MergeResult, error) {
+	if len(heads) == 0 {
+		return nil, errors.New("go-git: Merge() called without specifying a branch")
+	}
+	if opts == nil {
+		opts = &MergeOptions{}
 	}
-	args := []string{"merge", "-m='" + msg + "'"}
-	if !fastforward {
+	if opts.NoFastForward && (opts.FastForwardOnly || opts.Squash) {
+		return nil, errors.New("go-git: Merge() no fast-forward cannot be combined with fast-forward only or squash")
+	}
+	args := []string{"merge"}
+	if opts.Message != "" {
+		args = append(args, "--message="+opts.Message)
+	}
+	if opts.NoFastForward {
 		args = append(args, "--no-ff")
 	}
-	args = append(args, branch)
-	return execCommand(args...).Run()
+	if opts.FastForwardOnly {
+		args = append(args, "--ff-only")
+	}
+	if opts.Squash {
+		args = append(args, "--squash")
+	}
+	if opts.NoCommit {
+		args = append(args, "--no-commit")
+	}
+	if opts.Strategy != "" {
+		args = append(args, "--strategy="+opts.Strategy)
+	}
+	for _, opt := range opts.StrategyOptions {
+		args = append(args, "--strategy-option="+opt)
+	}
+	if opts.AllowUnrelatedHistories {
+		args = append(args, "--allow-unrelated-histories")
+	}
+	args = append(args, heads...)
+	stdout, stderr, err := r.run(args...)
+	if err != nil {
+		conflicts, cerr := r.conflictedFiles()
+		if cerr != nil || len(conflicts) == 0 {
+			return nil, err
+		}
+		return &MergeResult{Outcome: MergeConflict, Conflicts: conflicts}, nil
+	}
+	return &MergeResult{Outcome: parseMergeOutput(stdout + stderr)}, nil
+}
+
+// parseMergeOutput works out the outcome of a successful merge from its output.
+func parseMergeOutput(out string) MergeOutcome {
+	switch {
+	case strings.Contains(out, "Already up to date"):
+		return MergeUpToDate
+	case strings.Contains(out, "Squash commit -- not updating HEAD") || strings.Contains(out, "stopped before committing"):
+		return MergeUncommitted
+	case strings.Contains(out, "Fast-forward"):
+		return MergeFastForward
+	}
+	return MergeCommitted
 }
 
+// RemoteAdd calls Repository.RemoteAdd on the repository in the present working directory.
 func RemoteAdd(name, location string) error {
+	return (&Repository{}).RemoteAdd(name, location)
+}
+
+// RemoteAdd adds a remote named name located at location.
+func (r *Repository) RemoteAdd(name, location string) error {
 	if name == "" {
 		return errors.New("go-git: RemoteAdd() no name specified")
 	}
 	if location == "" {
 		return errors.New("go-git: RemoteAdd() no location specified")
 	}
-	return execCommand("remote", "add", name, location).Run()
+	_, _, err := r.run("remote", "add", name, location)
+	return err
 }
 
+// RemoteRemove calls Repository.RemoteRemove on the repository in the present working directory.
 func RemoteRemove(name string) error {
+	return (&Repository{}).RemoteRemove(name)
+}
+
+// RemoteRemove removes the named remote.
+func (r *Repository) RemoteRemove(name string) error {
 	if name == "" {
 		return errors.New("go-git: RemoteRemove() no name specified")
 	}
-	return execCommand("remote", "rm", name).Run()
+	_, _, err := r.run("remote", "rm", name)
+	return err
 }
 
+// RemoteSetURL calls Repository.RemoteSetURL on the repository in the present working directory.
 func RemoteSetURL(name, location string) error {
+	return (&Repository{}).RemoteSetURL(name, location)
+}
+
+// RemoteSetURL changes the location of the named remote.
+func (r *Repository) RemoteSetURL(name, location string) error {
 	if name == "" {
 		return errors.New("go-git: RemoteSetURL() no name specified")
 	}
 	if location == "" {
 		return errors.New("go-git: RemoteSetURL() no location specified")
 	}
-	return execCommand("remote", "set-url", name, location).Run()
+	_, _, err := r.run("remote", "set-url", name, location)
+	return err
 }
 
+// Fetch calls Repository.Fetch on the repository in the present working directory.
 func Fetch(remote string, branches ...string) error {
+	return (&Repository{}).Fetch(remote, branches...)
+}
+
+// Fetch fetches branches from remote. If no branches are provided the remote's configured
+// refspecs are fetched.
+func (r *Repository) Fetch(remote string, branches ...string) error {
 	if remote == "" {
 		return errors.New("go-git: Fetch() no remote specified")
 	}
-	args := []string{"fetch", remote}
-	if len(branches) == 0 {
-		args = append(args, "--all")
-	} else {
-		args = append(args, branches...)
+	args := append([]string{"fetch", remote}, branches...)
+	c := r.withProgress(r.command(args...))
+	cleanup, err := r.withTransport(c, r.remoteURL(remote, false))
+	if err != nil {
+		return err
 	}
-	return execCommand(args...).Run()
+	defer cleanup()
+	_, _, err = c.run()
+	return err
+}
+
+// Push calls Repository.Push on the repository in the present working directory.
+func Push(remote string, refspecs ...string) error {
+	return (&Repository{}).Push(remote, refspecs...)
 }
 
-func Pull(remote string, branches ...string) error {
+// Push updates remote with refspecs. If no refspecs are provided the current branch is pushed
+// according to the push.default configuration.
+func (r *Repository) Push(remote string, refspecs ...string) error {
 	if remote == "" {
-		return errors.New("go-git: Pull() no remote specified")
+		return errors.New("go-git: Push() no remote specified")
 	}
-	args := []string{"pull", remote}
-	if len(branches) == 0 {
-		args = append(args, "--all")
-	} else {
-		args = append(args, branches...)
+	args := append([]string{"push", remote}, refspecs...)
+	c := r.withProgress(r.command(args...))
+	cleanup, err := r.withTransport(c, r.remoteURL(remote, true))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	_, _, err = c.run()
+	return err
+}
+
+// Ref is a reference and the object it points at.
+type Ref struct {
+	Name string
+	Hash string
+}
+
+// LsRemote calls Repository.LsRemote on the repository in the present working directory.
+func LsRemote(remote string, patterns ...string) ([]Ref, error) {
+	return (&Repository{}).LsRemote(remote, patterns...)
+}
+
+// LsRemote lists the references in remote, which may be a remote name or url.
+// If patterns are provided only references matching them are listed.
+func (r *Repository) LsRemote(remote string, patterns ...string) ([]Ref, error) {
+	if remote == "" {
+		return nil, errors.New("go-git: LsRemote() no remote specified")
+	}
+	args := append([]string{"ls-remote", remote}, patterns...)
+	c := r.command(args...)
+	cleanup, err := r.withTransport(c, r.remoteURL(remote, false))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	stdout, _, err := c.run()
+	if err != nil {
+		return nil, err
+	}
+	return parseLsRemote(stdout), nil
+}
+
+// parseLsRemote parses the "<hash>\t<name>" lines printed by git ls-remote.
+func parseLsRemote(out string) []Ref {
+	refs := []Ref{}
+	for _, line := range strings.Split(out, "\n") {
+		hash, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		refs = append(refs, Ref{Name: name, Hash: hash})
+	}
+	return refs
+}
+
+// PullRebase selects how Pull integrates the fetched branch.
+type PullRebase int
+
+const (
+	// PullRebaseDefault leaves the choice to the pull.rebase configuration.
+	PullRebaseDefault PullRebase = iota
+	// PullRebaseFalse merges the fetched branch (--no-rebase).
+	PullRebaseFalse
+	// PullRebaseTrue rebases the current branch on top of the fetched branch (--rebase).
+	PullRebaseTrue
+	// PullRebaseMerges rebases while preserving local merge commits (--rebase=merges).
+	PullRebaseMerges
+)
+
+// PullOptions configures Pull. The zero value behaves like a plain git pull.
+type PullOptions struct {
+	FastForwardOnly bool       // FastForwardOnly refuses to pull unless the result is a fast-forward (--ff-only).
+	Rebase          PullRebase // Rebase selects between merging and rebasing.
+	Autostash       bool       // Autostash stashes local changes before the pull and reapplies them afterwards (--autostash).
+	Squash          bool       // Squash stages the fetched changes without committing them (--squash).
+}
+
+// PullOutcome describes what a successful Pull did to the current branch.
+type PullOutcome int
+
+const (
+	// PullUpToDate means there was nothing to integrate.
+	PullUpToDate PullOutcome = iota
+	// PullFastForward means the current branch was fast-forwarded.
+	PullFastForward
+	// PullMerge means a merge commit was created.
+	PullMerge
+	// PullRebased means local commits were rebased onto the fetched branch.
+	PullRebased
+	// PullSquashed means the fetched changes were staged without a commit.
+	PullSquashed
+	// PullConflict means the pull stopped with conflicts that must be resolved.
+	PullConflict
+)
+
+// PullResult is the result of Pull.
+type PullResult struct {
+	Outcome   PullOutcome
+	Conflicts []string // Conflicts are the conflicted paths when Outcome is PullConflict.
+}
+
+// Pull calls Repository.Pull on the repository in the present working directory.
+func Pull(remote string, opts *PullOptions, branches ...string) (*PullResult, error) {
+	return (&Repository{}).Pull(remote, opts, branches...)
+}
+
+// Pull fetches from remote and integrates the result into the current branch.
+// If no branches are provided the upstream of the current branch is pulled.
+// opts may be nil. A pull that stops on conflicts returns PullConflict and no error.
+func (r *Repository) Pull(remote string, opts *PullOptions, branches ...string) (*PullResult, error) {
+	if remote == "" {
+		return nil, errors.New("go-git: Pull() no remote specified")
+	}
+	if opts == nil {
+		opts = &PullOptions{}
+	}
+	if opts.Squash && (opts.Rebase == PullRebaseTrue || opts.Rebase == PullRebaseMerges) {
+		return nil, errors.New("go-git: Pull() squash cannot be combined with rebase")
+	}
+	args := []string{"pull"}
+	if opts.FastForwardOnly {
+		args = append(args, "--ff-only")
+	}
+	switch opts.Rebase {
+	case PullRebaseFalse:
+		args = append(args, "--no-rebase")
+	case PullRebaseTrue:
+		args = append(args, "--rebase")
+	case PullRebaseMerges:
+		args = append(args, "--rebase=merges")
+	}
+	if opts.Autostash {
+		args = append(args, "--autostash")
 	}
-	return execCommand(args...).Run()
+	if opts.Squash {
+		args = append(args, "--squash")
+	}
+	args = append(args, remote)
+	args = append(args, branches...)
+	c := r.withProgress(r.command(args...))
+	cleanup, err := r.withTransport(c, r.remoteURL(remote, false))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	stdout, stderr, err := c.run()
+	if err != nil {
+		conflicts, cerr := r.conflictedFiles()
+		if cerr != nil || len(conflicts) == 0 {
+			return nil, err
+		}
+		return &PullResult{Outcome: PullConflict, Conflicts: conflicts}, nil
+	}
+	return &PullResult{Outcome: parsePullOutput(stdout + stderr)}, nil
+}
 
+// parsePullOutput works out the outcome of a pull from its output. Only the start of each
+// line is looked at, so that paths in the diffstat cannot be mistaken for messages.
+func parsePullOutput(out string) PullOutcome {
+	outcome := PullMerge
+	for _, line := range strings.FieldsFunc(out, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		switch {
+		case strings.HasPrefix(line, "Already up to date") || strings.HasPrefix(line, "Current branch ") && strings.HasSuffix(line, " is up to date."):
+			return PullUpToDate
+		case strings.HasPrefix(line, "Squash commit -- not updating HEAD"):
+			return PullSquashed
+		case strings.HasPrefix(line, "Successfully rebased"):
+			return PullRebased
+		case line == "Fast-forward":
+			outcome = PullFastForward
+		}
+	}
+	return outcome
 }
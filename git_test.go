@@ -2,7 +2,11 @@ package git
 
 import (
 	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -11,6 +15,14 @@ type mockRunner struct{}
 func (m *mockRunner) Run() error {
 	return nil
 }
+
+// failingRunner is a mockRunner whose git exits unsuccessfully.
+type failingRunner struct{}
+
+func (m *failingRunner) Run() error {
+	return errors.New("exit status 1")
+}
+
 func equalErr(errA, errB error) bool {
 	if errA != nil && errB != nil {
 		return errA.Error() == errB.Error()
@@ -20,6 +32,60 @@ func equalErr(errA, errB error) bool {
 	return false
 }
 
+// testRepository makes commands run the real git and initializes a repository with an unborn
+// main branch in dir, or in a temporary directory if dir is empty.
+func testRepository(t *testing.T, dir string) *Repository {
+	t.Helper()
+	execCommand = func(args ...string) runner { return exec.Command("git", args...) }
+	if dir == "" {
+		dir = t.TempDir()
+	}
+	r, err := Init(dir, &InitOptions{InitialBranch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// testBareRepository is testRepository for a bare repository in a temporary directory.
+func testBareRepository(t *testing.T) *Repository {
+	t.Helper()
+	execCommand = func(args ...string) runner { return exec.Command("git", args...) }
+	r, err := Init(t.TempDir(), &InitOptions{Bare: true, InitialBranch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// testWriteFile writes content to the file name in the working tree of r, creating its directory.
+func testWriteFile(t *testing.T, r *Repository, name, content string) {
+	t.Helper()
+	path := filepath.Join(r.Dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testCommit commits everything in the working tree of r with message and returns the new commit.
+func testCommit(t *testing.T, r *Repository, message string) string {
+	t.Helper()
+	if _, _, err := r.run("add", "--all"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := r.run("-c", "user.name=go-git", "-c", "user.email=go-git@example.com", "commit", "--allow-empty", "--message="+message); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, err := r.run("rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(stdout)
+}
+
 func TestExecCommand(t *testing.T) {
 	execCommand()
 }
@@ -28,32 +94,50 @@ func TestInit(t *testing.T) {
 	cases := []struct {
 		CaseName   string
 		Dir        string
-		Template   string
+		Opts       *InitOptions
 		ExpectArgs []string
+		ExpectErr  error
 	}{
 		{
 			CaseName:   "Dir specified",
 			Dir:        "repo-dir",
-			Template:   "",
 			ExpectArgs: []string{"init", "repo-dir"},
 		},
 		{
 			CaseName:   "Dir not specified",
 			Dir:        "",
-			Template:   "",
 			ExpectArgs: []string{"init"},
 		},
 		{
 			CaseName:   "Template specified",
 			Dir:        "",
-			Template:   "template-dir",
-			ExpectArgs: []string{"init", "--template='template-dir'"},
+			Opts:       &InitOptions{Template: "template-dir"},
+			ExpectArgs: []string{"init", "--template=template-dir"},
 		},
 		{
 			CaseName:   "Dir and Template specified",
 			Dir:        "repo-dir",
-			Template:   "template-dir",
-			ExpectArgs: []string{"init", "--template='template-dir'", "repo-dir"},
+			Opts:       &InitOptions{Template: "template-dir"},
+			ExpectArgs: []string{"init", "--template=template-dir", "repo-dir"},
+		},
+		{
+			CaseName:   "Bare sha256 repository",
+			Dir:        "repo-dir",
+			Opts:       &InitOptions{Bare: true, InitialBranch: "main", ObjectFormat: "sha256"},
+			ExpectArgs: []string{"init", "--bare", "--initial-branch=main", "--object-format=sha256", "repo-dir"},
+		},
+		{
+			CaseName:   "Shared repository with a separate git dir",
+			Dir:        "repo-dir",
+			Opts:       &InitOptions{Shared: "group", SeparateGitDir: "git-dir"},
+			ExpectArgs: []string{"init", "--shared=group", "--separate-git-dir=git-dir", "repo-dir"},
+		},
+		{
+			CaseName:   "Unsupported object format",
+			Dir:        "repo-dir",
+			Opts:       &InitOptions{ObjectFormat: "md5"},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Init() unsupported object format md5"),
 		},
 	}
 	for _, c := range cases {
@@ -62,18 +146,38 @@ func TestInit(t *testing.T) {
 			gotArgs = args
 			return &mockRunner{}
 		}
-		Init(c.Dir, c.Template)
-		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) {
-			t.Errorf("\nexpected %v\ngot      : %v", c.ExpectArgs, gotArgs)
+		repo, gotErr := Init(c.Dir, c.Opts)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+		if gotErr == nil && repo.Dir != c.Dir {
+			t.Errorf("%s\nexpected repository in %q, got %q", c.CaseName, c.Dir, repo.Dir)
 		}
 	}
 }
 
+func TestOpen(t *testing.T) {
+	gotArgs := []string{}
+	execCommand = func(args ...string) runner {
+		gotArgs = args
+		return &mockRunner{}
+	}
+	repo, err := Open("repo-dir")
+	if err != nil || repo.Dir != "repo-dir" || !reflect.DeepEqual(gotArgs, []string{"rev-parse", "--git-dir"}) {
+		t.Errorf("expected repository in repo-dir, got %v, %v, %v", repo, err, gotArgs)
+	}
+}
+
 func TestClone(t *testing.T) {
 	cases := []struct {
 		CaseName   string
 		Repo       string
 		Dir        string
+		Opts       *CloneOptions
 		ExpectArgs []string
 		ExpectErr  error
 	}{
@@ -98,6 +202,49 @@ func TestClone(t *testing.T) {
 			ExpectArgs: []string{"clone", "repo-name", "dir-name"},
 			ExpectErr:  nil,
 		},
+		{
+			CaseName:   "Shallow single branch clone",
+			Repo:       "repo-name",
+			Dir:        "dir-name",
+			Opts:       &CloneOptions{Branch: "main", Depth: 1, SingleBranch: true},
+			ExpectArgs: []string{"clone", "--branch", "main", "--depth", "1", "--single-branch", "repo-name", "dir-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Blobless clone without checkout",
+			Repo:       "repo-name",
+			Opts:       &CloneOptions{Filter: "blob:none", NoCheckout: true},
+			ExpectArgs: []string{"clone", "--filter=blob:none", "--no-checkout", "repo-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Blobless sparse clone",
+			Repo:       "repo-name",
+			Opts:       &CloneOptions{Filter: "blob:none", Sparse: true},
+			ExpectArgs: []string{"clone", "--filter=blob:none", "--sparse", "repo-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Mirror clone using references",
+			Repo:       "repo-name",
+			Opts:       &CloneOptions{Mirror: true, Reference: []string{"ref-1", "ref-2"}, Dissociate: true},
+			ExpectArgs: []string{"clone", "--mirror", "--reference", "ref-1", "--reference", "ref-2", "--dissociate", "repo-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Bare clone with submodules",
+			Repo:       "repo-name",
+			Opts:       &CloneOptions{Bare: true, RecurseSubmodules: true},
+			ExpectArgs: []string{"clone", "--bare", "--recurse-submodules", "repo-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Negative depth",
+			Repo:       "repo-name",
+			Opts:       &CloneOptions{Depth: -1},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Clone() depth must not be negative"),
+		},
 	}
 	for _, c := range cases {
 		gotArgs := []string{}
@@ -105,7 +252,7 @@ func TestClone(t *testing.T) {
 			gotArgs = args
 			return &mockRunner{}
 		}
-		gotErr := Clone(c.Repo, c.Dir)
+		gotErr := Clone(c.Repo, c.Dir, c.Opts)
 		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
 			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
 				c.CaseName,
@@ -420,36 +567,59 @@ func TestDeleteTag(t *testing.T) {
 
 func TestMerge(t *testing.T) {
 	cases := []struct {
-		CaseName    string
-		Branch      string
-		Msg         string
-		FastForward bool
-		ExpectArgs  []string
-		ExpectErr   error
+		CaseName   string
+		Opts       *MergeOptions
+		Heads      []string
+		ExpectArgs []string
+		ExpectErr  error
 	}{
 		{
-			CaseName:    "Merge a branch without specifying a branch",
-			Branch:      "",
-			Msg:         "",
-			FastForward: true,
-			ExpectArgs:  []string{},
-			ExpectErr:   errors.New("go-git: Merge() called without specifying a branch"),
+			CaseName:   "Merge a branch without specifying a branch",
+			Heads:      []string{},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Merge() called without specifying a branch"),
+		},
+		{
+			CaseName:   "Merge a branch",
+			Opts:       &MergeOptions{Message: "merge message"},
+			Heads:      []string{"branch-name"},
+			ExpectArgs: []string{"merge", "--message=merge message", "branch-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Merge a branch without fastforwarding",
+			Opts:       &MergeOptions{Message: "merge-message", NoFastForward: true},
+			Heads:      []string{"branch-name"},
+			ExpectArgs: []string{"merge", "--message=merge-message", "--no-ff", "branch-name"},
+			ExpectErr:  nil,
 		},
 		{
-			CaseName:    "Merge a branch",
-			Branch:      "branch-name",
-			Msg:         "merge-message",
-			FastForward: true,
-			ExpectArgs:  []string{"merge", "-m='merge-message'", "branch-name"},
-			ExpectErr:   nil,
+			CaseName:   "Fast-forward only",
+			Opts:       &MergeOptions{FastForwardOnly: true},
+			Heads:      []string{"branch-name"},
+			ExpectArgs: []string{"merge", "--ff-only", "branch-name"},
+			ExpectErr:  nil,
 		},
 		{
-			CaseName:    "Merge a branch without fastforwarding",
-			Branch:      "branch-name",
-			Msg:         "merge-message",
-			FastForward: false,
-			ExpectArgs:  []string{"merge", "-m='merge-message'", "--no-ff", "branch-name"},
-			ExpectErr:   nil,
+			CaseName:   "Squash without committing using strategy options",
+			Opts:       &MergeOptions{Squash: true, NoCommit: true, Strategy: "ort", StrategyOptions: []string{"theirs", "renormalize"}},
+			Heads:      []string{"branch-name"},
+			ExpectArgs: []string{"merge", "--squash", "--no-commit", "--strategy=ort", "--strategy-option=theirs", "--strategy-option=renormalize", "branch-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Octopus merge of unrelated histories",
+			Opts:       &MergeOptions{AllowUnrelatedHistories: true},
+			Heads:      []string{"branch-1", "branch-2"},
+			ExpectArgs: []string{"merge", "--allow-unrelated-histories", "branch-1", "branch-2"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "No fast-forward with fast-forward only",
+			Opts:       &MergeOptions{NoFastForward: true, FastForwardOnly: true},
+			Heads:      []string{"branch-name"},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Merge() no fast-forward cannot be combined with fast-forward only or squash"),
 		},
 	}
 	for _, c := range cases {
@@ -458,7 +628,7 @@ func TestMerge(t *testing.T) {
 			gotArgs = args
 			return &mockRunner{}
 		}
-		gotErr := Merge(c.Branch, c.Msg, c.FastForward)
+		_, gotErr := Merge(c.Opts, c.Heads...)
 		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
 			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
 				c.CaseName,
@@ -469,6 +639,45 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestParseMergeOutput(t *testing.T) {
+	cases := []struct {
+		CaseName      string
+		Output        string
+		ExpectOutcome MergeOutcome
+	}{
+		{
+			CaseName:      "Up to date",
+			Output:        "Already up to date.\n",
+			ExpectOutcome: MergeUpToDate,
+		},
+		{
+			CaseName:      "Fast-forward",
+			Output:        "Updating feb52a9..15b4de0\nFast-forward\n f | 1 +\n",
+			ExpectOutcome: MergeFastForward,
+		},
+		{
+			CaseName:      "Merge commit",
+			Output:        "Merge made by the 'ort' strategy.\n f | 1 +\n",
+			ExpectOutcome: MergeCommitted,
+		},
+		{
+			CaseName:      "Squash",
+			Output:        "Updating feb52a9..15b4de0\nFast-forward\nSquash commit -- not updating HEAD\n",
+			ExpectOutcome: MergeUncommitted,
+		},
+		{
+			CaseName:      "No commit",
+			Output:        "Automatic merge went well; stopped before committing as requested\n",
+			ExpectOutcome: MergeUncommitted,
+		},
+	}
+	for _, c := range cases {
+		if got := parseMergeOutput(c.Output); got != c.ExpectOutcome {
+			t.Errorf("%s\nexpected : %v\ngot      : %v", c.CaseName, c.ExpectOutcome, got)
+		}
+	}
+}
+
 func TestRemoteAdd(t *testing.T) {
 	cases := []struct {
 		CaseName   string
@@ -619,7 +828,7 @@ func TestFetch(t *testing.T) {
 			CaseName:   "No branches specified",
 			Remote:     "remote-location",
 			Branches:   []string{},
-			ExpectArgs: []string{"fetch", "remote-location", "--all"},
+			ExpectArgs: []string{"fetch", "remote-location"},
 			ExpectErr:  nil,
 		},
 		{
@@ -647,10 +856,138 @@ func TestFetch(t *testing.T) {
 	}
 }
 
+func TestFetchConfiguredBranches(t *testing.T) {
+	dir := t.TempDir()
+	upstream := testRepository(t, filepath.Join(dir, "upstream"))
+	testCommit(t, upstream, "first")
+	r := &Repository{Dir: filepath.Join(dir, "clone")}
+	if err := r.Clone(upstream.Dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	second := testCommit(t, upstream, "second")
+	if err := r.Fetch("origin"); err != nil {
+		t.Fatal(err)
+	}
+	if stdout, _, _ := r.run("rev-parse", "origin/main"); strings.TrimSpace(stdout) != second {
+		t.Errorf("expected origin/main at %s, got %s", second, stdout)
+	}
+}
+
+func TestPush(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Remote     string
+		Refspecs   []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No remote specified",
+			Remote:     "",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Push() no remote specified"),
+		},
+		{
+			CaseName:   "No refspecs specified",
+			Remote:     "remote-name",
+			ExpectArgs: []string{"push", "remote-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Push specific refspecs",
+			Remote:     "remote-name",
+			Refspecs:   []string{"branch-1", "tag-1:refs/tags/tag-1"},
+			ExpectArgs: []string{"push", "remote-name", "branch-1", "tag-1:refs/tags/tag-1"},
+			ExpectErr:  nil,
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := Push(c.Remote, c.Refspecs...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestLsRemote(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Remote     string
+		Patterns   []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No remote specified",
+			Remote:     "",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: LsRemote() no remote specified"),
+		},
+		{
+			CaseName:   "List references matching patterns",
+			Remote:     "remote-name",
+			Patterns:   []string{"refs/heads/*"},
+			ExpectArgs: []string{"ls-remote", "remote-name", "refs/heads/*"},
+			ExpectErr:  nil,
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := LsRemote(c.Remote, c.Patterns...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseLsRemote(t *testing.T) {
+	out := "9fceb02d0ae598e95dc970b74767f19372d61af8\tHEAD\n" +
+		"9fceb02d0ae598e95dc970b74767f19372d61af8\trefs/heads/main\n"
+	expect := []Ref{
+		{Name: "HEAD", Hash: "9fceb02d0ae598e95dc970b74767f19372d61af8"},
+		{Name: "refs/heads/main", Hash: "9fceb02d0ae598e95dc970b74767f19372d61af8"},
+	}
+	if got := parseLsRemote(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %v\ngot      : %v", expect, got)
+	}
+}
+
+func TestProgressFlag(t *testing.T) {
+	gotArgs := []string{}
+	execCommand = func(args ...string) runner {
+		gotArgs = args
+		return &mockRunner{}
+	}
+	r := &Repository{Progress: func(Progress) {}}
+	r.Fetch("remote-name", "branch-1")
+	expect := []string{"fetch", "--progress", "remote-name", "branch-1"}
+	if !reflect.DeepEqual(expect, gotArgs) {
+		t.Errorf("expected : %v\ngot      : %v", expect, gotArgs)
+	}
+}
+
 func TestPull(t *testing.T) {
 	cases := []struct {
 		CaseName   string
 		Remote     string
+		Opts       *PullOptions
 		Branches   []string
 		ExpectArgs []string
 		ExpectErr  error
@@ -666,7 +1003,7 @@ func TestPull(t *testing.T) {
 			CaseName:   "No branches specified",
 			Remote:     "remote-location",
 			Branches:   []string{},
-			ExpectArgs: []string{"pull", "remote-location", "--all"},
+			ExpectArgs: []string{"pull", "remote-location"},
 			ExpectErr:  nil,
 		},
 		{
@@ -676,6 +1013,42 @@ func TestPull(t *testing.T) {
 			ExpectArgs: []string{"pull", "remote-name", "branch-1", "branch-2", "branch-3"},
 			ExpectErr:  nil,
 		},
+		{
+			CaseName:   "Fast-forward only",
+			Remote:     "remote-name",
+			Opts:       &PullOptions{FastForwardOnly: true},
+			ExpectArgs: []string{"pull", "--ff-only", "remote-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Rebase with autostash",
+			Remote:     "remote-name",
+			Opts:       &PullOptions{Rebase: PullRebaseTrue, Autostash: true},
+			Branches:   []string{"branch-1"},
+			ExpectArgs: []string{"pull", "--rebase", "--autostash", "remote-name", "branch-1"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Rebase merges",
+			Remote:     "remote-name",
+			Opts:       &PullOptions{Rebase: PullRebaseMerges},
+			ExpectArgs: []string{"pull", "--rebase=merges", "remote-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "No rebase with squash",
+			Remote:     "remote-name",
+			Opts:       &PullOptions{Rebase: PullRebaseFalse, Squash: true},
+			ExpectArgs: []string{"pull", "--no-rebase", "--squash", "remote-name"},
+			ExpectErr:  nil,
+		},
+		{
+			CaseName:   "Squash with rebase",
+			Remote:     "remote-name",
+			Opts:       &PullOptions{Rebase: PullRebaseTrue, Squash: true},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Pull() squash cannot be combined with rebase"),
+		},
 	}
 	for _, c := range cases {
 		gotArgs := []string{}
@@ -683,7 +1056,7 @@ func TestPull(t *testing.T) {
 			gotArgs = args
 			return &mockRunner{}
 		}
-		gotErr := Pull(c.Remote, c.Branches...)
+		_, gotErr := Pull(c.Remote, c.Opts, c.Branches...)
 		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
 			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
 				c.CaseName,
@@ -692,4 +1065,90 @@ func TestPull(t *testing.T) {
 			)
 		}
 	}
+
+	execCommand = func(args ...string) runner { return &failingRunner{} }
+	result, err := Pull("remote-name", &PullOptions{FastForwardOnly: true})
+	if result != nil || !equalErr(errors.New("go-git: git pull --ff-only remote-name: exit status 1"), err) {
+		t.Errorf("expected only the git error for a failed pull, got %+v, %v", result, err)
+	}
+}
+
+func TestPullConflicts(t *testing.T) {
+	dir := t.TempDir()
+	upstream := testRepository(t, filepath.Join(dir, "upstream"))
+	testWriteFile(t, upstream, "f", "base\n")
+	testCommit(t, upstream, "base")
+	r := &Repository{Dir: filepath.Join(dir, "clone")}
+	if err := r.Clone(upstream.Dir, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The diffstat of a clean pull must not be mistaken for a conflict.
+	testWriteFile(t, upstream, "docs/CONFLICTS.md", "CONFLICT\n")
+	testCommit(t, upstream, "docs")
+	result, err := r.Pull("origin", nil)
+	if expect := (&PullResult{Outcome: PullFastForward}); err != nil || !reflect.DeepEqual(expect, result) {
+		t.Errorf("expected : %+v\ngot      : %+v, %v", expect, result, err)
+	}
+
+	testWriteFile(t, upstream, "f", "theirs\n")
+	testCommit(t, upstream, "theirs")
+	testWriteFile(t, r, "f", "ours\n")
+	testCommit(t, r, "ours")
+	// git wants an identity before it tries to merge.
+	r.run("config", "user.name", "go-git")
+	r.run("config", "user.email", "go-git@example.com")
+	result, err = r.Pull("origin", &PullOptions{Rebase: PullRebaseFalse})
+	if expect := (&PullResult{Outcome: PullConflict, Conflicts: []string{"f"}}); err != nil || !reflect.DeepEqual(expect, result) {
+		t.Errorf("expected : %+v\ngot      : %+v, %v", expect, result, err)
+	}
+}
+
+func TestParsePullOutput(t *testing.T) {
+	cases := []struct {
+		CaseName      string
+		Output        string
+		ExpectOutcome PullOutcome
+	}{
+		{
+			CaseName:      "Up to date",
+			Output:        "Already up to date.\n",
+			ExpectOutcome: PullUpToDate,
+		},
+		{
+			CaseName:      "Fast-forward",
+			Output:        "Updating feb52a9..15b4de0\nFast-forward\n f | 1 +\n",
+			ExpectOutcome: PullFastForward,
+		},
+		{
+			CaseName:      "Merge",
+			Output:        "Merge made by the 'ort' strategy.\n f | 1 +\n",
+			ExpectOutcome: PullMerge,
+		},
+		{
+			CaseName:      "Rebase",
+			Output:        "Rebasing (1/1)\rSuccessfully rebased and updated refs/heads/main.\n",
+			ExpectOutcome: PullRebased,
+		},
+		{
+			CaseName:      "Squash",
+			Output:        "Updating feb52a9..15b4de0\nFast-forward\nSquash commit -- not updating HEAD\n",
+			ExpectOutcome: PullSquashed,
+		},
+		{
+			CaseName:      "Rebase up to date",
+			Output:        "Current branch main is up to date.\n",
+			ExpectOutcome: PullUpToDate,
+		},
+		{
+			CaseName:      "Diffstat naming messages",
+			Output:        "Merge made by the 'ort' strategy.\n Fast-forward | 1 +\n Already up to date | 1 +\n",
+			ExpectOutcome: PullMerge,
+		},
+	}
+	for _, c := range cases {
+		if got := parsePullOutput(c.Output); got != c.ExpectOutcome {
+			t.Errorf("%s\nexpected : %v\ngot      : %v", c.CaseName, c.ExpectOutcome, got)
+		}
+	}
 }
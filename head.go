@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// HeadInfo describes what is checked out.
+type HeadInfo struct {
+	Ref      string // Ref is the full name of the checked out branch, e.g. "refs/heads/main", or "" when detached.
+	Branch   string // Branch is the short name of the checked out branch, or "" when detached.
+	Detached bool   // Detached is true when HEAD points directly at a commit.
+	Hash     string // Hash is the commit HEAD points at, or "" when the branch is unborn.
+	Unborn   bool   // Unborn is true when the branch has no commits yet.
+	Upstream string // Upstream is the short name of the branch's upstream, e.g. "origin/main", or "" if it has none.
+}
+
+// Head reports what is checked out.
+func (r *Repository) Head() (*HeadInfo, error) {
+	h := &HeadInfo{}
+	ref, err := r.SymbolicRef("HEAD")
+	switch {
+	case err == nil:
+		h.Ref = ref
+		h.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	case errors.Is(err, ErrNotSymbolicRef):
+		h.Detached = true
+	default:
+		return nil, err
+	}
+	stdout, _, err := r.run("rev-parse", "--quiet", "--verify", "HEAD^{commit}")
+	switch {
+	case err == nil:
+		h.Hash = strings.TrimSpace(stdout)
+	case exitStatus(err) == 1:
+		h.Unborn = true
+	default:
+		return nil, err
+	}
+	if h.Ref != "" {
+		stdout, _, err := r.run("for-each-ref", "--format=%(upstream:short)", h.Ref)
+		if err != nil {
+			return nil, err
+		}
+		h.Upstream = strings.TrimSpace(stdout)
+	}
+	return h, nil
+}
+
+// ErrNotSymbolicRef is returned by SymbolicRef when the reference is not symbolic,
+// for example when HEAD is detached.
+var ErrNotSymbolicRef = errors.New("go-git: not a symbolic reference")
+
+// SymbolicRef returns the full name of the reference the symbolic reference name points at.
+func (r *Repository) SymbolicRef(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("go-git: SymbolicRef() no name specified")
+	}
+	stdout, _, err := r.run("symbolic-ref", "--quiet", name)
+	if err != nil {
+		if exitStatus(err) == 1 {
+			return "", ErrNotSymbolicRef
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// SetSymbolicRef points the symbolic reference name at target, recording reason in the reflog if it is not empty.
+func (r *Repository) SetSymbolicRef(name, target, reason string) error {
+	if name == "" {
+		return errors.New("go-git: SetSymbolicRef() no name specified")
+	}
+	if target == "" {
+		return errors.New("go-git: SetSymbolicRef() no target specified")
+	}
+	args := []string{"symbolic-ref"}
+	if reason != "" {
+		args = append(args, "-m", reason)
+	}
+	args = append(args, name, target)
+	_, _, err := r.run(args...)
+	return err
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHead(t *testing.T) {
+	r := testRepository(t, "")
+	got, err := r.Head()
+	expect := &HeadInfo{Ref: "refs/heads/main", Branch: "main", Unborn: true}
+	if err != nil || !reflect.DeepEqual(expect, got) {
+		t.Errorf("unborn branch\nexpected : %+v\ngot      : %+v, %v", expect, got, err)
+	}
+
+	hash := testCommit(t, r, "initial")
+	r.run("update-ref", "refs/remotes/origin/main", hash)
+	r.RemoteAdd("origin", "https://example.com/repo.git")
+	r.run("config", "branch.main.remote", "origin")
+	r.run("config", "branch.main.merge", "refs/heads/main")
+	got, err = r.Head()
+	expect = &HeadInfo{Ref: "refs/heads/main", Branch: "main", Hash: hash, Upstream: "origin/main"}
+	if err != nil || !reflect.DeepEqual(expect, got) {
+		t.Errorf("branch with upstream\nexpected : %+v\ngot      : %+v, %v", expect, got, err)
+	}
+
+	r.run("checkout", "--detach")
+	got, err = r.Head()
+	expect = &HeadInfo{Detached: true, Hash: hash}
+	if err != nil || !reflect.DeepEqual(expect, got) {
+		t.Errorf("detached HEAD\nexpected : %+v\ngot      : %+v, %v", expect, got, err)
+	}
+	if _, err := r.SymbolicRef("HEAD"); err != ErrNotSymbolicRef {
+		t.Errorf("expected ErrNotSymbolicRef, got %v", err)
+	}
+}
+
+func TestSetSymbolicRef(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Name       string
+		Target     string
+		Reason     string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No name specified",
+			Target:     "refs/heads/main",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: SetSymbolicRef() no name specified"),
+		},
+		{
+			CaseName:   "No target specified",
+			Name:       "HEAD",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: SetSymbolicRef() no target specified"),
+		},
+		{
+			CaseName:   "Point HEAD at a branch",
+			Name:       "HEAD",
+			Target:     "refs/heads/main",
+			ExpectArgs: []string{"symbolic-ref", "HEAD", "refs/heads/main"},
+		},
+		{
+			CaseName:   "With a reflog message",
+			Name:       "refs/remotes/origin/HEAD",
+			Target:     "refs/remotes/origin/main",
+			Reason:     "default branch changed",
+			ExpectArgs: []string{"symbolic-ref", "-m", "default branch changed", "refs/remotes/origin/HEAD", "refs/remotes/origin/main"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := (&Repository{}).SetSymbolicRef(c.Name, c.Target, c.Reason)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MergeMessage is an informational message about a path produced while merging.
+type MergeMessage struct {
+	Paths   []string // Paths are the paths the message is about.
+	Type    string   // Type is a stable identifier of the kind of message, e.g. "Auto-merging" or "CONFLICT (contents)".
+	Message string
+}
+
+// MergePreviewResult is the result of MergePreview.
+type MergePreviewResult struct {
+	// Tree is the tree the merge results in. When there are conflicts it contains
+	// the conflicted files with conflict markers.
+	Tree      string
+	Clean     bool           // Clean is true when the merge has no conflicts.
+	Conflicts []string       // Conflicts are the conflicted paths.
+	Messages  []MergeMessage // Messages are git's messages about the merge.
+}
+
+// MergePreview merges ours and theirs in memory and reports the resulting tree or the conflicts,
+// without touching the index or working tree, so it also works in bare repositories.
+// If base is empty the merge base is computed by git; specifying it requires git 2.40 or later.
+func (r *Repository) MergePreview(base, ours, theirs string) (*MergePreviewResult, error) {
+	if ours == "" || theirs == "" {
+		return nil, errors.New("go-git: MergePreview() ours and theirs must be specified")
+	}
+	args := []string{"merge-tree", "--write-tree", "-z", "--name-only", "--messages"}
+	if base != "" {
+		args = append(args, "--merge-base="+base)
+	}
+	args = append(args, ours, theirs)
+	stdout, _, err := r.run(args...)
+	// merge-tree exits with 1 when the merge has conflicts.
+	if err != nil && exitStatus(err) != 1 {
+		return nil, err
+	}
+	return parseMergeTree(stdout)
+}
+
+// parseMergeTree parses the output of git merge-tree --write-tree -z --name-only --messages.
+func parseMergeTree(out string) (*MergePreviewResult, error) {
+	fields := strings.Split(out, "\x00")
+	if len(fields) == 0 || fields[0] == "" {
+		return nil, errors.New("go-git: MergePreview() unexpected merge-tree output")
+	}
+	result := &MergePreviewResult{Tree: strings.TrimSpace(fields[0]), Clean: true, Conflicts: []string{}, Messages: []MergeMessage{}}
+	i := 1
+	for ; i < len(fields) && fields[i] != ""; i++ {
+		result.Conflicts = append(result.Conflicts, fields[i])
+	}
+	result.Clean = len(result.Conflicts) == 0
+	for i++; i < len(fields) && fields[i] != ""; {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || i+n+2 >= len(fields) {
+			return nil, errors.New("go-git: MergePreview() unexpected merge-tree output")
+		}
+		m := MergeMessage{Paths: fields[i+1 : i+1+n]}
+		m.Type = fields[i+1+n]
+		m.Message = strings.TrimSuffix(fields[i+2+n], "\n")
+		result.Messages = append(result.Messages, m)
+		i += n + 3
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergePreviewArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Base       string
+		Ours       string
+		Theirs     string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "Missing side",
+			Ours:       "main",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: MergePreview() ours and theirs must be specified"),
+		},
+		{
+			CaseName:   "Computed merge base",
+			Ours:       "main",
+			Theirs:     "feature",
+			ExpectArgs: []string{"merge-tree", "--write-tree", "-z", "--name-only", "--messages", "main", "feature"},
+			ExpectErr:  errors.New("go-git: MergePreview() unexpected merge-tree output"),
+		},
+		{
+			CaseName:   "Explicit merge base",
+			Base:       "v1.0",
+			Ours:       "main",
+			Theirs:     "feature",
+			ExpectArgs: []string{"merge-tree", "--write-tree", "-z", "--name-only", "--messages", "--merge-base=v1.0", "main", "feature"},
+			ExpectErr:  errors.New("go-git: MergePreview() unexpected merge-tree output"),
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := (&Repository{}).MergePreview(c.Base, c.Ours, c.Theirs)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseMergeTree(t *testing.T) {
+	cases := []struct {
+		CaseName     string
+		Output       string
+		ExpectResult *MergePreviewResult
+	}{
+		{
+			CaseName: "Clean merge",
+			Output:   "57afd8e11c3559d488e021f21be04034492840cb\x00\x00",
+			ExpectResult: &MergePreviewResult{
+				Tree:      "57afd8e11c3559d488e021f21be04034492840cb",
+				Clean:     true,
+				Conflicts: []string{},
+				Messages:  []MergeMessage{},
+			},
+		},
+		{
+			CaseName: "Conflicts",
+			Output: "57afd8e11c3559d488e021f21be04034492840cb\x00f\x00g\x00\x00" +
+				"1\x00f\x00Auto-merging\x00Auto-merging f\n\x00" +
+				"1\x00f\x00CONFLICT (contents)\x00CONFLICT (content): Merge conflict in f\n\x00" +
+				"2\x00g\x00h\x00CONFLICT (rename/delete)\x00CONFLICT (rename/delete): g renamed to h\n\x00",
+			ExpectResult: &MergePreviewResult{
+				Tree:      "57afd8e11c3559d488e021f21be04034492840cb",
+				Clean:     false,
+				Conflicts: []string{"f", "g"},
+				Messages: []MergeMessage{
+					{Paths: []string{"f"}, Type: "Auto-merging", Message: "Auto-merging f"},
+					{Paths: []string{"f"}, Type: "CONFLICT (contents)", Message: "CONFLICT (content): Merge conflict in f"},
+					{Paths: []string{"g", "h"}, Type: "CONFLICT (rename/delete)", Message: "CONFLICT (rename/delete): g renamed to h"},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		got, err := parseMergeTree(c.Output)
+		if err != nil || !reflect.DeepEqual(c.ExpectResult, got) {
+			t.Errorf("%s\nexpected : %+v\ngot      : %+v, %v", c.CaseName, c.ExpectResult, got, err)
+		}
+	}
+}
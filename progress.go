@@ -0,0 +1,85 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Progress is a single progress report parsed from git's standard error.
+type Progress struct {
+	Phase      string // Phase is the stage being reported, e.g. "Receiving objects" or "Resolving deltas".
+	Remote     bool   // Remote is true when the report was relayed from the remote end.
+	Percent    int    // Percent is the completion percentage, or -1 when git does not know the total.
+	Current    int64  // Current is the number of items processed so far.
+	Total      int64  // Total is the number of items to process, or 0 when unknown.
+	Bytes      int64  // Bytes is the amount of data transferred so far, when reported.
+	Throughput int64  // Throughput is the transfer rate in bytes per second, when reported.
+	Done       bool   // Done is true for the final report of a phase.
+}
+
+var progressLine = regexp.MustCompile(`^(remote: )?([A-Za-z][A-Za-z ]*):\s+(?:(\d+)% \()?(\d+)(?:/(\d+))?\)?(?:, ([\d.]+) (bytes|KiB|MiB|GiB)(?: \| ([\d.]+) (bytes|KiB|MiB|GiB)/s)?)?(, done\.?)?`)
+
+// parseProgress parses a single line of git progress output.
+func parseProgress(line string) (Progress, bool) {
+	m := progressLine.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return Progress{}, false
+	}
+	p := Progress{
+		Phase:   m[2],
+		Remote:  m[1] != "",
+		Percent: -1,
+		Done:    m[10] != "",
+	}
+	if m[3] != "" {
+		p.Percent, _ = strconv.Atoi(m[3])
+	}
+	p.Current, _ = strconv.ParseInt(m[4], 10, 64)
+	if m[5] != "" {
+		p.Total, _ = strconv.ParseInt(m[5], 10, 64)
+	}
+	if m[6] != "" {
+		p.Bytes = parseSize(m[6], m[7])
+	}
+	if m[8] != "" {
+		p.Throughput = parseSize(m[8], m[9])
+	}
+	return p, true
+}
+
+// parseSize converts a size as printed by git into bytes.
+func parseSize(n, unit string) int64 {
+	f, _ := strconv.ParseFloat(n, 64)
+	switch unit {
+	case "KiB":
+		f *= 1 << 10
+	case "MiB":
+		f *= 1 << 20
+	case "GiB":
+		f *= 1 << 30
+	}
+	return int64(f)
+}
+
+// progressWriter splits git's standard error into lines, which git terminates
+// with either a carriage return or a newline, and reports those that parse as progress.
+type progressWriter struct {
+	fn      func(Progress)
+	pending []byte
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	w.pending = append(w.pending, b...)
+	for {
+		i := strings.IndexAny(string(w.pending), "\r\n")
+		if i < 0 {
+			break
+		}
+		if p, ok := parseProgress(string(w.pending[:i])); ok {
+			w.fn(p)
+		}
+		w.pending = w.pending[i+1:]
+	}
+	return len(b), nil
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProgress(t *testing.T) {
+	cases := []struct {
+		CaseName       string
+		Line           string
+		ExpectProgress Progress
+		ExpectOK       bool
+	}{
+		{
+			CaseName:       "Remote enumeration",
+			Line:           "remote: Enumerating objects: 20, done.",
+			ExpectProgress: Progress{Phase: "Enumerating objects", Remote: true, Percent: -1, Current: 20, Done: true},
+			ExpectOK:       true,
+		},
+		{
+			CaseName:       "Remote compression",
+			Line:           "remote: Compressing objects:  35% (7/20)",
+			ExpectProgress: Progress{Phase: "Compressing objects", Remote: true, Percent: 35, Current: 7, Total: 20},
+			ExpectOK:       true,
+		},
+		{
+			CaseName:       "Receiving with throughput",
+			Line:           "Receiving objects:  45% (45/100), 1.50 MiB | 512.00 KiB/s",
+			ExpectProgress: Progress{Phase: "Receiving objects", Percent: 45, Current: 45, Total: 100, Bytes: 1572864, Throughput: 524288},
+			ExpectOK:       true,
+		},
+		{
+			CaseName:       "Writing finished",
+			Line:           "Writing objects: 100% (3/3), 250 bytes | 250.00 KiB/s, done.",
+			ExpectProgress: Progress{Phase: "Writing objects", Percent: 100, Current: 3, Total: 3, Bytes: 250, Throughput: 256000, Done: true},
+			ExpectOK:       true,
+		},
+		{
+			CaseName:       "Resolving deltas",
+			Line:           "Resolving deltas: 100% (30/30), done.",
+			ExpectProgress: Progress{Phase: "Resolving deltas", Percent: 100, Current: 30, Total: 30, Done: true},
+			ExpectOK:       true,
+		},
+		{
+			CaseName: "Not progress",
+			Line:     "Cloning into 'repo'...",
+			ExpectOK: false,
+		},
+	}
+	for _, c := range cases {
+		got, ok := parseProgress(c.Line)
+		if ok != c.ExpectOK || !reflect.DeepEqual(c.ExpectProgress, got) {
+			t.Errorf("%s\nexpected : %+v, %v\ngot      : %+v, %v", c.CaseName, c.ExpectProgress, c.ExpectOK, got, ok)
+		}
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	got := []Progress{}
+	w := &progressWriter{fn: func(p Progress) { got = append(got, p) }}
+	w.Write([]byte("Cloning into 'repo'...\nReceiving objects:  50% (1/2)\rReceiving obj"))
+	w.Write([]byte("ects: 100% (2/2), done.\n"))
+	expect := []Progress{
+		{Phase: "Receiving objects", Percent: 50, Current: 1, Total: 2},
+		{Phase: "Receiving objects", Percent: 100, Current: 2, Total: 2, Done: true},
+	}
+	if !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
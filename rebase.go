@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// Conflict describes a rebase, cherry-pick or revert that stopped because applying
+// a commit produced conflicts. Resolve the paths, stage them and continue, or abort.
+type Conflict struct {
+	Commit string   // Commit is the commit that was being applied.
+	Paths  []string // Paths are the conflicted paths.
+}
+
+// RebaseOptions configures Rebase.
+type RebaseOptions struct {
+	Autosquash   bool     // Autosquash moves fixup! and squash! commits next to the commits they amend (--autosquash).
+	RebaseMerges bool     // RebaseMerges recreates merge commits instead of flattening them (--rebase-merges).
+	Autostash    bool     // Autostash stashes local changes before the rebase and reapplies them afterwards (--autostash).
+	Exec         []string // Exec are shell commands run after each rebased commit (--exec).
+}
+
+// Rebase reapplies the commits of the current branch that are not in upstream on top of onto,
+// or on top of upstream if onto is empty. opts may be nil.
+// If the rebase stops on conflicts it returns a non-nil Conflict and err is nil;
+// use RebaseContinue, RebaseSkip or RebaseAbort to proceed.
+func (r *Repository) Rebase(upstream, onto string, opts *RebaseOptions) (*Conflict, error) {
+	if upstream == "" {
+		return nil, errors.New("go-git: Rebase() no upstream specified")
+	}
+	return r.sequence(r.command(rebaseArgs(upstream, onto, opts, false)...), "REBASE_HEAD")
+}
+
+// rebaseArgs builds the arguments of a git rebase. opts may be nil.
+func rebaseArgs(upstream, onto string, opts *RebaseOptions, interactive bool) []string {
+	if opts == nil {
+		opts = &RebaseOptions{}
+	}
+	args := []string{"rebase"}
+	if interactive || opts.Autosquash {
+		// Before git 2.44 --autosquash only takes effect in interactive rebases.
+		args = append(args, "--interactive")
+	}
+	if opts.Autosquash {
+		args = append(args, "--autosquash")
+	}
+	if opts.RebaseMerges {
+		args = append(args, "--rebase-merges")
+	}
+	if opts.Autostash {
+		args = append(args, "--autostash")
+	}
+	for _, cmd := range opts.Exec {
+		args = append(args, "--exec", cmd)
+	}
+	if onto != "" {
+		args = append(args, "--onto", onto)
+	}
+	return append(args, upstream)
+}
+
+// RebaseContinue continues a stopped rebase once the conflicts have been resolved and staged.
+func (r *Repository) RebaseContinue() (*Conflict, error) {
+	return r.sequence(r.command("rebase", "--continue"), "REBASE_HEAD")
+}
+
+// RebaseSkip continues a stopped rebase, dropping the commit that could not be applied.
+func (r *Repository) RebaseSkip() (*Conflict, error) {
+	return r.sequence(r.command("rebase", "--skip"), "REBASE_HEAD")
+}
+
+// RebaseAbort abandons a stopped rebase and restores the original branch.
+func (r *Repository) RebaseAbort() error {
+	_, _, err := r.run("rebase", "--abort")
+	return err
+}
+
+// sequence runs c, a command that applies commits one at a time, without prompting
+// for commit messages. If it stops on conflicts they are returned as a Conflict
+// naming the commit that head points at.
+func (r *Repository) sequence(c *command, head string) (*Conflict, error) {
+	c.env = append([]string{"GIT_EDITOR=true", "GIT_SEQUENCE_EDITOR=true"}, c.env...)
+	_, _, err := c.run()
+	if err == nil {
+		return nil, nil
+	}
+	paths, cerr := r.conflictedFiles()
+	if cerr != nil || len(paths) == 0 {
+		return nil, err
+	}
+	commit, _, _ := r.run("rev-parse", "--verify", "--quiet", head)
+	return &Conflict{Commit: strings.TrimSpace(commit), Paths: paths}, nil
+}
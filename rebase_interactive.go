@@ -0,0 +1,198 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RebaseAction is the command of an interactive rebase todo entry.
+type RebaseAction string
+
+// The actions of an interactive rebase todo list.
+const (
+	RebasePick      RebaseAction = "pick"
+	RebaseReword    RebaseAction = "reword"
+	RebaseEdit      RebaseAction = "edit"
+	RebaseSquash    RebaseAction = "squash"
+	RebaseFixup     RebaseAction = "fixup"
+	RebaseDrop      RebaseAction = "drop"
+	RebaseExec      RebaseAction = "exec"
+	RebaseBreak     RebaseAction = "break"
+	RebaseLabel     RebaseAction = "label"
+	RebaseReset     RebaseAction = "reset"
+	RebaseMerge     RebaseAction = "merge"
+	RebaseUpdateRef RebaseAction = "update-ref"
+	RebaseNoop      RebaseAction = "noop"
+)
+
+// rebaseCommitActions are the actions that take a commit.
+var rebaseCommitActions = map[RebaseAction]bool{
+	RebasePick:   true,
+	RebaseReword: true,
+	RebaseEdit:   true,
+	RebaseSquash: true,
+	RebaseFixup:  true,
+	RebaseDrop:   true,
+}
+
+// rebaseActionAbbrevs maps the one letter abbreviations git accepts to their actions.
+var rebaseActionAbbrevs = map[string]RebaseAction{
+	"p": RebasePick,
+	"r": RebaseReword,
+	"e": RebaseEdit,
+	"s": RebaseSquash,
+	"f": RebaseFixup,
+	"d": RebaseDrop,
+	"x": RebaseExec,
+	"b": RebaseBreak,
+	"l": RebaseLabel,
+	"t": RebaseReset,
+	"m": RebaseMerge,
+	"u": RebaseUpdateRef,
+}
+
+// RebaseTodo is an entry of an interactive rebase todo list.
+type RebaseTodo struct {
+	Action  RebaseAction
+	Flag    string // Flag is an option of the action, such as the -C of "fixup -C".
+	Commit  string // Commit is the commit of pick, reword, edit, squash, fixup and drop entries.
+	Subject string // Subject is the subject line of Commit, for information only.
+	Arg     string // Arg is the argument of the other actions, e.g. the command of an exec entry.
+
+	// Message, if set, replaces the message of the commit produced by a pick, reword,
+	// edit, squash or fixup entry without opening an editor. For an edit entry the
+	// message is applied once the rebase continues. Other actions do not take a Message.
+	Message string
+}
+
+// String formats t as a todo list line.
+func (t RebaseTodo) String() string {
+	fields := []string{string(t.Action)}
+	if t.Flag != "" {
+		fields = append(fields, t.Flag)
+	}
+	if rebaseCommitActions[t.Action] {
+		fields = append(fields, t.Commit)
+		if t.Subject != "" {
+			fields = append(fields, t.Subject)
+		}
+	} else if t.Arg != "" {
+		fields = append(fields, t.Arg)
+	}
+	return strings.Join(fields, " ")
+}
+
+// parseRebaseTodo parses a todo list as written by git, skipping comments and blank lines.
+func parseRebaseTodo(todo string) []RebaseTodo {
+	entries := []RebaseTodo{}
+	for _, line := range strings.Split(todo, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		action, rest, _ := strings.Cut(line, " ")
+		t := RebaseTodo{Action: RebaseAction(action)}
+		if a, ok := rebaseActionAbbrevs[action]; ok {
+			t.Action = a
+		}
+		if rebaseCommitActions[t.Action] {
+			if strings.HasPrefix(rest, "-") {
+				t.Flag, rest, _ = strings.Cut(rest, " ")
+			}
+			t.Commit, t.Subject, _ = strings.Cut(rest, " ")
+		} else {
+			t.Arg = rest
+		}
+		entries = append(entries, t)
+	}
+	return entries
+}
+
+// rebaseMessageActions are the actions whose entries may carry a Message.
+var rebaseMessageActions = map[RebaseAction]bool{
+	RebasePick:   true,
+	RebaseReword: true,
+	RebaseEdit:   true,
+	RebaseSquash: true,
+	RebaseFixup:  true,
+}
+
+// formatRebaseTodo formats entries as a todo list for git. Entries with a Message are
+// followed by an exec entry amending the resulting commit with it, and rewording
+// is done that way rather than through an editor.
+func formatRebaseTodo(entries []RebaseTodo) (string, error) {
+	var b strings.Builder
+	for _, t := range entries {
+		if t.Message != "" && !rebaseMessageActions[t.Action] {
+			return "", errors.New("go-git: RebaseInteractive() Message is not supported for " + string(t.Action) + " entries")
+		}
+		if t.Message != "" && t.Action == RebaseReword {
+			t.Action = RebasePick
+		}
+		b.WriteString(t.String() + "\n")
+		if t.Message != "" {
+			b.WriteString(RebaseTodo{Action: RebaseExec, Arg: amendCommand(t.Message)}.String() + "\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// amendCommand returns a shell command amending the message of HEAD to msg.
+// The message is escaped for printf %b so that it fits on a single todo line.
+func amendCommand(msg string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`).Replace(msg)
+	return "printf '%b' " + shellQuote(escaped) + " | git commit --amend --quiet --file=-"
+}
+
+// RebaseInteractive runs an interactive rebase like Rebase, letting edit rewrite the todo
+// list git generates instead of an editor. Entries may be reordered, removed or changed,
+// and commit messages supplied through RebaseTodo.Message.
+// If the rebase stops on conflicts it returns a non-nil Conflict and err is nil. An edit
+// or break entry stops the rebase without a Conflict; use RebaseContinue to proceed.
+func (r *Repository) RebaseInteractive(upstream, onto string, opts *RebaseOptions, edit func([]RebaseTodo) ([]RebaseTodo, error)) (*Conflict, error) {
+	if upstream == "" {
+		return nil, errors.New("go-git: RebaseInteractive() no upstream specified")
+	}
+	if edit == nil {
+		return nil, errors.New("go-git: RebaseInteractive() no todo list editor specified")
+	}
+	dir, err := os.MkdirTemp("", "go-git-rebase-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	todoFile := filepath.Join(dir, "git-rebase-todo")
+	args := rebaseArgs(upstream, onto, opts, true)
+
+	// Let git generate the todo list, then empty it so that the rebase stops
+	// immediately with nothing to do.
+	c := r.command(args...)
+	c.env = append(c.env, "GIT_SEQUENCE_EDITOR=f() { cp \"$1\" "+shellQuote(todoFile)+" && : > \"$1\"; }; f")
+	_, _, err = c.run()
+	todo, rerr := os.ReadFile(todoFile)
+	if rerr != nil {
+		if err == nil {
+			err = rerr
+		}
+		return nil, err
+	}
+	entries, err := edit(parseRebaseTodo(string(todo)))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("go-git: RebaseInteractive() empty todo list")
+	}
+	list, err := formatRebaseTodo(entries)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(todoFile, []byte(list), 0600); err != nil {
+		return nil, err
+	}
+	c = r.command(args...)
+	c.env = append(c.env, "GIT_SEQUENCE_EDITOR=cp "+shellQuote(todoFile))
+	return r.sequence(c, "REBASE_HEAD")
+}
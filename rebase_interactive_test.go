@@ -0,0 +1,74 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRebaseTodo(t *testing.T) {
+	todo := "pick e88aa75 first commit\n" +
+		"f -C 48d2474 amend! first commit\n" +
+		"exec make test\n" +
+		"label onto\n" +
+		"\n" +
+		"# Rebase 62b92b4..e88aa75 onto 62b92b4 (3 commands)\n"
+	expect := []RebaseTodo{
+		{Action: RebasePick, Commit: "e88aa75", Subject: "first commit"},
+		{Action: RebaseFixup, Flag: "-C", Commit: "48d2474", Subject: "amend! first commit"},
+		{Action: RebaseExec, Arg: "make test"},
+		{Action: RebaseLabel, Arg: "onto"},
+	}
+	if got := parseRebaseTodo(todo); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
+
+func TestFormatRebaseTodo(t *testing.T) {
+	entries := []RebaseTodo{
+		{Action: RebaseReword, Commit: "e88aa75", Subject: "first commit", Message: "new subject\n\nit's done"},
+		{Action: RebaseSquash, Commit: "48d2474"},
+		{Action: RebaseReword, Commit: "62b92b4"},
+		{Action: RebaseEdit, Commit: "9f3c2a1", Message: "edited"},
+		{Action: RebaseBreak},
+	}
+	expect := "pick e88aa75 first commit\n" +
+		`exec printf '%b' 'new subject\n\nit'\''s done' | git commit --amend --quiet --file=-` + "\n" +
+		"squash 48d2474\n" +
+		"reword 62b92b4\n" +
+		"edit 9f3c2a1\n" +
+		`exec printf '%b' edited | git commit --amend --quiet --file=-` + "\n" +
+		"break\n"
+	if got, err := formatRebaseTodo(entries); got != expect || err != nil {
+		t.Errorf("expected : %v\ngot      : %v, %v", expect, got, err)
+	}
+
+	for _, action := range []RebaseAction{RebaseDrop, RebaseExec, RebaseBreak, RebaseLabel, RebaseReset} {
+		entries := []RebaseTodo{{Action: action, Commit: "e88aa75", Message: "new subject"}}
+		expectErr := errors.New("go-git: RebaseInteractive() Message is not supported for " + string(action) + " entries")
+		if _, err := formatRebaseTodo(entries); !equalErr(expectErr, err) {
+			t.Errorf("%s\nexpected : %v\ngot      : %v", action, expectErr, err)
+		}
+	}
+}
+
+func TestAmendCommand(t *testing.T) {
+	expect := `printf '%b' 'a\\b\nc' | git commit --amend --quiet --file=-`
+	if got := amendCommand("a\\b\nc"); got != expect {
+		t.Errorf("expected : %v\ngot      : %v", expect, got)
+	}
+}
+
+func TestRebaseInteractiveArguments(t *testing.T) {
+	execCommand = func(args ...string) runner {
+		return &mockRunner{}
+	}
+	edit := func(e []RebaseTodo) ([]RebaseTodo, error) { return e, nil }
+	r := &Repository{}
+	if _, err := r.RebaseInteractive("", "", nil, edit); !equalErr(errors.New("go-git: RebaseInteractive() no upstream specified"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := r.RebaseInteractive("main", "", nil, nil); !equalErr(errors.New("go-git: RebaseInteractive() no todo list editor specified"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRebase(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Upstream   string
+		Onto       string
+		Opts       *RebaseOptions
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No upstream specified",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Rebase() no upstream specified"),
+		},
+		{
+			CaseName:   "Rebase onto upstream",
+			Upstream:   "main",
+			ExpectArgs: []string{"rebase", "main"},
+		},
+		{
+			CaseName:   "Rebase onto another branch",
+			Upstream:   "main",
+			Onto:       "release",
+			ExpectArgs: []string{"rebase", "--onto", "release", "main"},
+		},
+		{
+			CaseName:   "All options",
+			Upstream:   "main",
+			Opts:       &RebaseOptions{Autosquash: true, RebaseMerges: true, Autostash: true, Exec: []string{"make", "make test"}},
+			ExpectArgs: []string{"rebase", "--interactive", "--autosquash", "--rebase-merges", "--autostash", "--exec", "make", "--exec", "make test", "main"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		conflict, gotErr := (&Repository{}).Rebase(c.Upstream, c.Onto, c.Opts)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) || conflict != nil {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr, conflict,
+			)
+		}
+	}
+}
+
+func TestRebaseControl(t *testing.T) {
+	gotArgs := [][]string{}
+	execCommand = func(args ...string) runner {
+		gotArgs = append(gotArgs, args)
+		return &mockRunner{}
+	}
+	r := &Repository{}
+	r.RebaseContinue()
+	r.RebaseSkip()
+	r.RebaseAbort()
+	expect := [][]string{{"rebase", "--continue"}, {"rebase", "--skip"}, {"rebase", "--abort"}}
+	if !reflect.DeepEqual(expect, gotArgs) {
+		t.Errorf("expected : %v\ngot      : %v", expect, gotArgs)
+	}
+}
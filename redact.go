@@ -0,0 +1,77 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets scrubbed by Redact.
+const redacted = "***"
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+
+	urlUserinfo = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://)[^/@\s]+@`)
+	authHeader  = regexp.MustCompile(`(?i)((?:authorization|extraheader)\s*[:=]\s*).*`)
+)
+
+// RegisterSecret makes Redact scrub secret wherever it appears, until UnregisterSecret is called.
+// Passwords returned by a repository's Credentials callback need not be registered; they are
+// scrubbed from the errors of the commands that used them.
+func RegisterSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	for _, s := range secrets {
+		if s == secret {
+			return
+		}
+	}
+	secrets = append(secrets, secret)
+}
+
+// UnregisterSecret stops Redact from scrubbing secret, e.g. once a rotated token has expired.
+func UnregisterSecret(secret string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	for i, s := range secrets {
+		if s == secret {
+			secrets = append(secrets[:i], secrets[i+1:]...)
+			return
+		}
+	}
+}
+
+// Redact scrubs credentials from s: the userinfo of urls, the values of Authorization
+// and http.extraHeader settings, and every registered secret. Errors returned by this
+// package are already redacted; Redact is exported for callers' own logs.
+func Redact(s string) string {
+	secretsMu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	secretsMu.RUnlock()
+	s = urlUserinfo.ReplaceAllString(s, "${1}"+redacted+"@")
+	return authHeader.ReplaceAllString(s, "${1}"+redacted)
+}
+
+// redactWith is Redact that also scrubs the secrets of a single command.
+func redactWith(s string, extra []string) string {
+	for _, secret := range extra {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return Redact(s)
+}
+
+// redactArgs returns a copy of args redacted with redactWith.
+func redactArgs(args []string, extra []string) []string {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		out[i] = redactWith(arg, extra)
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RefTransaction is a set of reference updates applied atomically with Commit:
+// either all of them succeed or none is applied.
+type RefTransaction struct {
+	repo *Repository
+	ops  []string
+	err  error
+}
+
+// RefTransactionError is returned by RefTransaction.Commit when an update fails.
+type RefTransactionError struct {
+	Ref string // Ref is the reference that could not be updated, if git named it.
+	Err error  // Err is the error git failed with.
+}
+
+func (e *RefTransactionError) Error() string {
+	return "go-git: ref transaction failed on " + e.Ref + ": " + e.Err.Error()
+}
+
+func (e *RefTransactionError) Unwrap() error {
+	return e.Err
+}
+
+// RefTransaction starts a new reference transaction.
+func (r *Repository) RefTransaction() *RefTransaction {
+	return &RefTransaction{repo: r}
+}
+
+// Create creates ref pointing at hash. The transaction fails if ref already exists.
+func (t *RefTransaction) Create(ref, hash string) {
+	t.add("create", ref, hash)
+}
+
+// Update points ref at hash, creating it if necessary. If old is not empty the
+// transaction fails unless ref currently points at old.
+func (t *RefTransaction) Update(ref, hash, old string) {
+	t.add("update", ref, hash, old)
+}
+
+// Delete deletes ref. If old is not empty the transaction fails unless ref currently points at old.
+func (t *RefTransaction) Delete(ref, old string) {
+	t.add("delete", ref, old)
+}
+
+// Verify makes the transaction fail unless ref currently points at old, or,
+// if old is empty, unless ref does not exist. ref is not changed.
+func (t *RefTransaction) Verify(ref, old string) {
+	t.add("verify", ref, old)
+}
+
+// add queues an instruction for git update-ref --stdin. Trailing empty values are omitted.
+func (t *RefTransaction) add(op string, values ...string) {
+	for _, v := range values {
+		if strings.ContainsAny(v, " \t\r\n") {
+			t.err = errors.New("go-git: RefTransaction invalid value " + strconv.Quote(v))
+		}
+	}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	t.ops = append(t.ops, strings.Join(append([]string{op}, values...), " "))
+}
+
+var lockedRef = regexp.MustCompile(`ref '([^']+)'`)
+
+// Commit applies the transaction, recording reason in the reflogs if it is not empty.
+// If an update fails nothing is applied and a *RefTransactionError is returned.
+func (t *RefTransaction) Commit(reason string) error {
+	if t.err != nil {
+		return t.err
+	}
+	if len(t.ops) == 0 {
+		return errors.New("go-git: RefTransaction.Commit() no updates specified")
+	}
+	args := []string{"update-ref"}
+	if reason != "" {
+		args = append(args, "-m", reason)
+	}
+	c := t.repo.command(append(args, "--stdin")...)
+	c.stdin = strings.NewReader(strings.Join(t.ops, "\n") + "\n")
+	_, stderr, err := c.run()
+	if err != nil {
+		txErr := &RefTransactionError{Err: err}
+		if m := lockedRef.FindStringSubmatch(stderr); m != nil {
+			txErr.Ref = m[1]
+		}
+		return txErr
+	}
+	t.ops = nil
+	return nil
+}
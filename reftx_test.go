@@ -0,0 +1,65 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRefTransaction(t *testing.T) {
+	tx := (&Repository{}).RefTransaction()
+	tx.Create("refs/heads/new", "aaaa")
+	tx.Update("refs/heads/main", "bbbb", "cccc")
+	tx.Update("refs/heads/other", "bbbb", "")
+	tx.Delete("refs/tags/old", "")
+	tx.Verify("refs/heads/pinned", "dddd")
+	expect := []string{
+		"create refs/heads/new aaaa",
+		"update refs/heads/main bbbb cccc",
+		"update refs/heads/other bbbb",
+		"delete refs/tags/old",
+		"verify refs/heads/pinned dddd",
+	}
+	if !reflect.DeepEqual(expect, tx.ops) {
+		t.Errorf("expected : %v\ngot      : %v", expect, tx.ops)
+	}
+}
+
+func TestRefTransactionInvalid(t *testing.T) {
+	err := (&Repository{}).RefTransaction().Commit("")
+	if !equalErr(errors.New("go-git: RefTransaction.Commit() no updates specified"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+	tx := (&Repository{}).RefTransaction()
+	tx.Update("refs/heads/main", "aaaa\ndelete refs/heads/other", "")
+	if err := tx.Commit(""); !equalErr(errors.New(`go-git: RefTransaction invalid value "aaaa\ndelete refs/heads/other"`), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestRefTransactionCommit(t *testing.T) {
+	r := testRepository(t, "")
+	first := testCommit(t, r, "first")
+	second := testCommit(t, r, "second")
+
+	tx := r.RefTransaction()
+	tx.Create("refs/heads/release", first)
+	tx.Create("refs/tags/v1", first)
+	if err := tx.Commit("release"); err != nil {
+		t.Fatal(err)
+	}
+
+	tx = r.RefTransaction()
+	tx.Update("refs/heads/release", second, first)
+	tx.Update("refs/tags/v1", second, second)
+	err := tx.Commit("")
+	var txErr *RefTransactionError
+	if !errors.As(err, &txErr) || txErr.Ref != "refs/tags/v1" {
+		t.Fatalf("expected failure on refs/tags/v1, got %v", err)
+	}
+	stdout, _, _ := r.run("rev-parse", "refs/heads/release")
+	if got := strings.TrimSpace(stdout); got != first {
+		t.Errorf("expected refs/heads/release to be left at %s, got %s", first, got)
+	}
+}
@@ -0,0 +1,86 @@
+package git
+
+import "errors"
+
+// ResetMode selects what Reset updates besides the current branch.
+type ResetMode int
+
+const (
+	// ResetMixed resets the index but not the working tree (--mixed).
+	ResetMixed ResetMode = iota
+	// ResetSoft leaves both the index and the working tree untouched (--soft).
+	ResetSoft
+	// ResetHard resets the index and the working tree, discarding local changes (--hard).
+	ResetHard
+	// ResetKeep resets the index and the working tree but refuses to discard local changes (--keep).
+	ResetKeep
+	// ResetMerge resets like ResetKeep but keeps changes that are not staged, aborting merges (--merge).
+	ResetMerge
+)
+
+var resetFlags = map[ResetMode]string{
+	ResetMixed: "--mixed",
+	ResetSoft:  "--soft",
+	ResetHard:  "--hard",
+	ResetKeep:  "--keep",
+	ResetMerge: "--merge",
+}
+
+// Reset resets the current branch to revision, updating the index and working tree according to mode.
+// If revision is empty HEAD is used. If pathspecs are provided only the index entries of matching
+// paths are reset, which requires ResetMixed.
+func (r *Repository) Reset(mode ResetMode, revision string, pathspecs ...string) error {
+	flag, ok := resetFlags[mode]
+	if !ok {
+		return errors.New("go-git: Reset() unknown reset mode")
+	}
+	args := []string{"reset"}
+	if len(pathspecs) > 0 {
+		if mode != ResetMixed {
+			return errors.New("go-git: Reset() pathspecs can only be reset in mixed mode")
+		}
+	} else {
+		args = append(args, flag)
+	}
+	if revision != "" {
+		args = append(args, revision)
+	}
+	if len(pathspecs) > 0 {
+		args = append(args, "--")
+		args = append(args, pathspecs...)
+	}
+	_, _, err := r.run(args...)
+	return err
+}
+
+// RestoreOptions configures Restore. If neither Staged nor Worktree is set the working tree is restored.
+type RestoreOptions struct {
+	Source   string // Source is the revision to restore from. The default is the index, or HEAD when Staged is set (--source).
+	Staged   bool   // Staged restores the index, unstaging changes (--staged).
+	Worktree bool   // Worktree restores the working tree (--worktree).
+}
+
+// Restore restores the paths matching pathspecs in the working tree and/or the index.
+// opts may be nil.
+func (r *Repository) Restore(opts *RestoreOptions, pathspecs ...string) error {
+	if len(pathspecs) == 0 {
+		return errors.New("go-git: Restore() no pathspecs specified")
+	}
+	if opts == nil {
+		opts = &RestoreOptions{}
+	}
+	args := []string{"restore"}
+	if opts.Source != "" {
+		args = append(args, "--source="+opts.Source)
+	}
+	if opts.Staged {
+		args = append(args, "--staged")
+	}
+	if opts.Worktree {
+		args = append(args, "--worktree")
+	}
+	args = append(args, "--")
+	args = append(args, pathspecs...)
+	_, _, err := r.run(args...)
+	return err
+}
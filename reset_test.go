@@ -0,0 +1,119 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Mode       ResetMode
+		Revision   string
+		Pathspecs  []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "Mixed reset to HEAD",
+			Mode:       ResetMixed,
+			ExpectArgs: []string{"reset", "--mixed"},
+		},
+		{
+			CaseName:   "Hard reset to a revision",
+			Mode:       ResetHard,
+			Revision:   "HEAD~1",
+			ExpectArgs: []string{"reset", "--hard", "HEAD~1"},
+		},
+		{
+			CaseName:   "Keep reset to a revision",
+			Mode:       ResetKeep,
+			Revision:   "main",
+			ExpectArgs: []string{"reset", "--keep", "main"},
+		},
+		{
+			CaseName:   "Reset pathspecs",
+			Mode:       ResetMixed,
+			Revision:   "main",
+			Pathspecs:  []string{"file-1", "dir"},
+			ExpectArgs: []string{"reset", "main", "--", "file-1", "dir"},
+		},
+		{
+			CaseName:   "Soft reset of pathspecs",
+			Mode:       ResetSoft,
+			Pathspecs:  []string{"file-1"},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Reset() pathspecs can only be reset in mixed mode"),
+		},
+		{
+			CaseName:   "Unknown mode",
+			Mode:       ResetMode(42),
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Reset() unknown reset mode"),
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := (&Repository{}).Reset(c.Mode, c.Revision, c.Pathspecs...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestRestore(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Opts       *RestoreOptions
+		Pathspecs  []string
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No pathspecs",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: Restore() no pathspecs specified"),
+		},
+		{
+			CaseName:   "Restore working tree",
+			Pathspecs:  []string{"file-1"},
+			ExpectArgs: []string{"restore", "--", "file-1"},
+		},
+		{
+			CaseName:   "Unstage",
+			Opts:       &RestoreOptions{Staged: true},
+			Pathspecs:  []string{"file-1"},
+			ExpectArgs: []string{"restore", "--staged", "--", "file-1"},
+		},
+		{
+			CaseName:   "Restore both from a revision",
+			Opts:       &RestoreOptions{Source: "v1.0", Staged: true, Worktree: true},
+			Pathspecs:  []string{"dir"},
+			ExpectArgs: []string{"restore", "--source=v1.0", "--staged", "--worktree", "--", "dir"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := (&Repository{}).Restore(c.Opts, c.Pathspecs...)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
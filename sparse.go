@@ -0,0 +1,86 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// SparseCheckoutInit enables a sparse checkout of the top level files only. cone restricts
+// patterns to directories, which is faster and required by a sparse index; otherwise
+// patterns follow .gitignore syntax.
+func (r *Repository) SparseCheckoutInit(cone bool) error {
+	mode := "--no-cone"
+	if cone {
+		mode = "--cone"
+	}
+	_, _, err := r.run("sparse-checkout", "init", mode)
+	return err
+}
+
+// SparseCheckoutSet replaces the sparse checkout patterns, directories in cone mode, and updates
+// the working tree. The mode chosen by SparseCheckoutInit is kept; sparse checkout is enabled
+// in cone mode if it was not.
+func (r *Repository) SparseCheckoutSet(patterns ...string) error {
+	return r.sparseCheckoutPatterns("set", patterns)
+}
+
+// SparseCheckoutAdd adds to the sparse checkout patterns and updates the working tree.
+func (r *Repository) SparseCheckoutAdd(patterns ...string) error {
+	if len(patterns) == 0 {
+		return errors.New("go-git: SparseCheckoutAdd() no patterns specified")
+	}
+	return r.sparseCheckoutPatterns("add", patterns)
+}
+
+// sparseCheckoutPatterns runs the sparse-checkout subcommand with patterns passed on standard input,
+// so that patterns starting with a dash are not taken as options.
+func (r *Repository) sparseCheckoutPatterns(subcommand string, patterns []string) error {
+	for _, p := range patterns {
+		if strings.Contains(p, "\n") {
+			return errors.New("go-git: invalid sparse checkout pattern " + p)
+		}
+	}
+	c := r.command("sparse-checkout", subcommand, "--stdin")
+	c.stdin = strings.NewReader(strings.Join(patterns, "\n") + "\n")
+	_, _, err := c.run()
+	return err
+}
+
+// SparseCheckoutList returns the sparse checkout patterns, the included directories in cone mode.
+func (r *Repository) SparseCheckoutList() ([]string, error) {
+	stdout, _, err := r.run("sparse-checkout", "list")
+	if err != nil {
+		return nil, err
+	}
+	patterns := []string{}
+	for _, p := range strings.Split(stdout, "\n") {
+		if p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns, nil
+}
+
+// SparseCheckoutDisable checks out all files again and turns sparse checkout off.
+func (r *Repository) SparseCheckoutDisable() error {
+	_, _, err := r.run("sparse-checkout", "disable")
+	return err
+}
+
+// SparseCheckoutFiles lists the tracked files matched by the sparse checkout patterns, that is
+// the files present in the working tree. Without a sparse checkout all tracked files are listed.
+func (r *Repository) SparseCheckoutFiles() ([]string, error) {
+	// -t tags files outside the sparse checkout, which have the skip-worktree bit set, with "S".
+	stdout, _, err := r.run("ls-files", "-t", "-z")
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range splitNul(stdout) {
+		tag, path, _ := strings.Cut(entry, " ")
+		if tag != "S" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
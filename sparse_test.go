@@ -0,0 +1,97 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSparseCheckoutArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Call       func(r *Repository) error
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "Init cone",
+			Call:       func(r *Repository) error { return r.SparseCheckoutInit(true) },
+			ExpectArgs: []string{"sparse-checkout", "init", "--cone"},
+		},
+		{
+			CaseName:   "Init patterns",
+			Call:       func(r *Repository) error { return r.SparseCheckoutInit(false) },
+			ExpectArgs: []string{"sparse-checkout", "init", "--no-cone"},
+		},
+		{
+			CaseName:   "Set",
+			Call:       func(r *Repository) error { return r.SparseCheckoutSet("services/api", "libs") },
+			ExpectArgs: []string{"sparse-checkout", "set", "--stdin"},
+		},
+		{
+			CaseName:   "Add nothing",
+			Call:       func(r *Repository) error { return r.SparseCheckoutAdd() },
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: SparseCheckoutAdd() no patterns specified"),
+		},
+		{
+			CaseName:   "Add multiline pattern",
+			Call:       func(r *Repository) error { return r.SparseCheckoutAdd("docs\n/*") },
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: invalid sparse checkout pattern docs\n/*"),
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := c.Call(&Repository{})
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestSparseCheckout(t *testing.T) {
+	r := testRepository(t, "")
+	for _, name := range []string{"README.md", "api/main.go", "web/index.html", "docs/guide.md"} {
+		testWriteFile(t, r, name, name)
+	}
+	testCommit(t, r, "first")
+
+	if err := r.SparseCheckoutInit(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SparseCheckoutSet("api"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SparseCheckoutAdd("docs"); err != nil {
+		t.Fatal(err)
+	}
+	patterns, err := r.SparseCheckoutList()
+	if expect := []string{"api", "docs"}; err != nil || !reflect.DeepEqual(expect, patterns) {
+		t.Errorf("expected : %v\ngot      : %v, %v", expect, patterns, err)
+	}
+	files, err := r.SparseCheckoutFiles()
+	if expect := []string{"README.md", "api/main.go", "docs/guide.md"}; err != nil || !reflect.DeepEqual(expect, files) {
+		t.Errorf("expected : %v\ngot      : %v, %v", expect, files, err)
+	}
+	if _, err := os.Stat(filepath.Join(r.Dir, "web")); !os.IsNotExist(err) {
+		t.Errorf("expected web to be removed from the working tree, got %v", err)
+	}
+
+	if err := r.SparseCheckoutDisable(); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ = r.SparseCheckoutFiles(); len(files) != 4 {
+		t.Errorf("expected all files after disabling, got %v", files)
+	}
+}
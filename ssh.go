@@ -0,0 +1,81 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// SSHOptions configures the ssh command git uses to reach SSH remotes.
+type SSHOptions struct {
+	KeyFile string // KeyFile is the private key to authenticate with.
+	// Key is a private key held in memory. It is written to a private temporary
+	// file for the duration of each command. Key and KeyFile are mutually exclusive.
+	Key                   []byte
+	KnownHostsFile        string   // KnownHostsFile replaces the user's known_hosts file.
+	StrictHostKeyChecking string   // StrictHostKeyChecking is passed to ssh as is, e.g. "yes", "no" or "accept-new".
+	Args                  []string // Args are additional arguments passed to ssh.
+}
+
+// withSSH points c's GIT_SSH_COMMAND at an ssh command configured from the repository's SSH options.
+// The returned function removes any temporary key file and must be called once c has run.
+func (r *Repository) withSSH(c *command) (func(), error) {
+	if r.SSH == nil {
+		return func() {}, nil
+	}
+	opts := r.SSH
+	if opts.KeyFile != "" && opts.Key != nil {
+		return nil, errors.New("go-git: SSH options specify both a key and a key file")
+	}
+	cleanup := func() {}
+	keyFile := opts.KeyFile
+	if opts.Key != nil {
+		f, err := os.CreateTemp("", "go-git-ssh-key-")
+		if err != nil {
+			return nil, err
+		}
+		cleanup = func() { os.Remove(f.Name()) }
+		key := opts.Key
+		if len(key) > 0 && key[len(key)-1] != '\n' {
+			key = append(key[:len(key):len(key)], '\n')
+		}
+		_, err = f.Write(key)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		keyFile = f.Name()
+	}
+	c.env = append(c.env, "GIT_SSH_COMMAND="+sshCommand(keyFile, opts))
+	return cleanup, nil
+}
+
+// sshCommand builds the shell command line git runs to connect over ssh.
+func sshCommand(keyFile string, opts *SSHOptions) string {
+	args := []string{"ssh"}
+	if keyFile != "" {
+		args = append(args, "-i", keyFile, "-o", "IdentitiesOnly=yes")
+	}
+	if opts.KnownHostsFile != "" {
+		args = append(args, "-o", "UserKnownHostsFile="+opts.KnownHostsFile)
+	}
+	if opts.StrictHostKeyChecking != "" {
+		args = append(args, "-o", "StrictHostKeyChecking="+opts.StrictHostKeyChecking)
+	}
+	args = append(args, opts.Args...)
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// shellQuote quotes s for use as a single word in a POSIX shell command line.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=./:@,+") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
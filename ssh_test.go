@@ -0,0 +1,88 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSSHCommand(t *testing.T) {
+	cases := []struct {
+		CaseName  string
+		KeyFile   string
+		Opts      *SSHOptions
+		ExpectCmd string
+	}{
+		{
+			CaseName:  "No options",
+			Opts:      &SSHOptions{},
+			ExpectCmd: "ssh",
+		},
+		{
+			CaseName:  "Key and known hosts",
+			KeyFile:   "/keys/deploy key",
+			Opts:      &SSHOptions{KnownHostsFile: "/keys/known_hosts", StrictHostKeyChecking: "yes"},
+			ExpectCmd: "ssh -i '/keys/deploy key' -o IdentitiesOnly=yes -o UserKnownHostsFile=/keys/known_hosts -o StrictHostKeyChecking=yes",
+		},
+		{
+			CaseName:  "Extra arguments",
+			Opts:      &SSHOptions{Args: []string{"-p", "2222", "-o", "ProxyCommand=nc -X 5 %h %p"}},
+			ExpectCmd: "ssh -p 2222 -o 'ProxyCommand=nc -X 5 %h %p'",
+		},
+	}
+	for _, c := range cases {
+		if got := sshCommand(c.KeyFile, c.Opts); got != c.ExpectCmd {
+			t.Errorf("%s\nexpected : %v\ngot      : %v", c.CaseName, c.ExpectCmd, got)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"plain":     "plain",
+		"":          "''",
+		"two words": "'two words'",
+		"it's":      `'it'\''s'`,
+	}
+	for in, expect := range cases {
+		if got := shellQuote(in); got != expect {
+			t.Errorf("shellQuote(%q)\nexpected : %v\ngot      : %v", in, expect, got)
+		}
+	}
+}
+
+func TestWithSSHKey(t *testing.T) {
+	r := &Repository{SSH: &SSHOptions{Key: []byte("private key")}}
+	c := r.command("fetch", "origin")
+	cleanup, err := r.withSSH(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.env) != 1 || !strings.HasPrefix(c.env[0], "GIT_SSH_COMMAND=ssh -i ") {
+		t.Fatalf("unexpected environment %v", c.env)
+	}
+	keyFile := strings.Fields(c.env[0])[2]
+	info, err := os.Stat(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected key file mode 0600, got %v", info.Mode().Perm())
+	}
+	if key, _ := os.ReadFile(keyFile); string(key) != "private key\n" {
+		t.Errorf("unexpected key file content %q", key)
+	}
+	cleanup()
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Errorf("expected key file to be removed, got %v", err)
+	}
+}
+
+func TestWithSSHKeyAndKeyFile(t *testing.T) {
+	r := &Repository{SSH: &SSHOptions{Key: []byte("private key"), KeyFile: "key-file"}}
+	_, err := r.withSSH(r.command("fetch", "origin"))
+	if !equalErr(errors.New("go-git: SSH options specify both a key and a key file"), err) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package git
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StashEntry is an entry on the stash. Entries are identified by the hash of their
+// stash commit rather than by position, so an entry stays valid while others are
+// pushed or dropped.
+type StashEntry struct {
+	Index   int       // Index is the position of the entry when it was listed, as in stash@{Index}.
+	Hash    string    // Hash is the stash commit.
+	Message string    // Message is the message the entry was saved with.
+	Branch  string    // Branch is the branch the entry was created on.
+	Date    time.Time // Date is when the entry was created.
+}
+
+// StashPushOptions configures StashPush.
+type StashPushOptions struct {
+	Message          string   // Message describes the entry (--message).
+	IncludeUntracked bool     // IncludeUntracked also stashes untracked files (--include-untracked).
+	KeepIndex        bool     // KeepIndex leaves staged changes in place (--keep-index).
+	Pathspecs        []string // Pathspecs limits the stash to matching paths.
+}
+
+// StashPush saves local changes to a new stash entry and reverts them.
+// If there are no local changes nothing is stashed and a nil entry is returned.
+// opts may be nil.
+func (r *Repository) StashPush(opts *StashPushOptions) (*StashEntry, error) {
+	if opts == nil {
+		opts = &StashPushOptions{}
+	}
+	before, err := r.stashHead()
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"stash", "push"}
+	if opts.Message != "" {
+		args = append(args, "--message="+opts.Message)
+	}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if len(opts.Pathspecs) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Pathspecs...)
+	}
+	if _, _, err := r.run(args...); err != nil {
+		return nil, err
+	}
+	entries, err := r.StashList()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 || entries[0].Hash == before {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// stashHead returns the hash of the newest stash entry, or "" if the stash is empty.
+func (r *Repository) stashHead() (string, error) {
+	stdout, _, err := r.run("rev-parse", "--quiet", "--verify", "refs/stash")
+	if err != nil {
+		if exitStatus(err) == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// StashList lists the stash entries, newest first.
+func (r *Repository) StashList() ([]StashEntry, error) {
+	stdout, _, err := r.run("stash", "list", "--format=%H%x00%gd%x00%gs%x00%ct")
+	if err != nil {
+		return nil, err
+	}
+	return parseStashList(stdout), nil
+}
+
+// parseStashList parses the output of git stash list in the format used by StashList.
+func parseStashList(out string) []StashEntry {
+	entries := []StashEntry{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		e := StashEntry{Hash: fields[0]}
+		index := strings.TrimSuffix(strings.TrimPrefix(fields[1], "stash@{"), "}")
+		e.Index, _ = strconv.Atoi(index)
+		subject := fields[2]
+		if rest, ok := strings.CutPrefix(subject, "WIP on "); ok {
+			e.Branch, e.Message, _ = strings.Cut(rest, ": ")
+		} else if rest, ok := strings.CutPrefix(subject, "On "); ok {
+			e.Branch, e.Message, _ = strings.Cut(rest, ": ")
+		} else {
+			e.Message = subject
+		}
+		if ts, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			e.Date = time.Unix(ts, 0)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// stashRef returns the current stash@{n} name of entry.
+func (r *Repository) stashRef(entry StashEntry) (string, error) {
+	entries, err := r.StashList()
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.Hash == entry.Hash {
+			return "stash@{" + strconv.Itoa(e.Index) + "}", nil
+		}
+	}
+	return "", errors.New("go-git: stash entry " + entry.Hash + " not found")
+}
+
+// StashShow returns the changes recorded in entry as a patch.
+func (r *Repository) StashShow(entry StashEntry) (string, error) {
+	if entry.Hash == "" {
+		return "", errors.New("go-git: StashShow() no stash entry specified")
+	}
+	stdout, _, err := r.run("stash", "show", "--patch", entry.Hash)
+	return stdout, err
+}
+
+// StashApply applies entry to the working tree, keeping it on the stash.
+// If restoreIndex is true staged changes are restored as staged (--index).
+// When applying stops on conflicts the conflicted paths are returned and err is nil.
+func (r *Repository) StashApply(entry StashEntry, restoreIndex bool) (conflicts []string, err error) {
+	if entry.Hash == "" {
+		return nil, errors.New("go-git: StashApply() no stash entry specified")
+	}
+	return r.stashApply("apply", entry.Hash, restoreIndex)
+}
+
+// StashPop applies entry to the working tree and drops it from the stash.
+// If restoreIndex is true staged changes are restored as staged (--index).
+// When applying stops on conflicts the conflicted paths are returned, err is nil
+// and entry is kept on the stash.
+func (r *Repository) StashPop(entry StashEntry, restoreIndex bool) (conflicts []string, err error) {
+	if entry.Hash == "" {
+		return nil, errors.New("go-git: StashPop() no stash entry specified")
+	}
+	ref, err := r.stashRef(entry)
+	if err != nil {
+		return nil, err
+	}
+	return r.stashApply("pop", ref, restoreIndex)
+}
+
+func (r *Repository) stashApply(subcommand, ref string, restoreIndex bool) ([]string, error) {
+	args := []string{"stash", subcommand}
+	if restoreIndex {
+		args = append(args, "--index")
+	}
+	_, _, err := r.run(append(args, ref)...)
+	if err == nil {
+		return nil, nil
+	}
+	conflicts, cerr := r.conflictedFiles()
+	if cerr != nil || len(conflicts) == 0 {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// StashDrop removes entry from the stash.
+func (r *Repository) StashDrop(entry StashEntry) error {
+	if entry.Hash == "" {
+		return errors.New("go-git: StashDrop() no stash entry specified")
+	}
+	ref, err := r.stashRef(entry)
+	if err != nil {
+		return err
+	}
+	_, _, err = r.run("stash", "drop", ref)
+	return err
+}
+
+// StashClear removes all entries from the stash.
+func (r *Repository) StashClear() error {
+	_, _, err := r.run("stash", "clear")
+	return err
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStashPush(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Opts       *StashPushOptions
+		ExpectArgs [][]string
+	}{
+		{
+			CaseName: "No options",
+			Opts:     nil,
+			ExpectArgs: [][]string{
+				{"rev-parse", "--quiet", "--verify", "refs/stash"},
+				{"stash", "push"},
+				{"stash", "list", "--format=%H%x00%gd%x00%gs%x00%ct"},
+			},
+		},
+		{
+			CaseName: "All options",
+			Opts:     &StashPushOptions{Message: "wip", IncludeUntracked: true, KeepIndex: true, Pathspecs: []string{"dir"}},
+			ExpectArgs: [][]string{
+				{"rev-parse", "--quiet", "--verify", "refs/stash"},
+				{"stash", "push", "--message=wip", "--include-untracked", "--keep-index", "--", "dir"},
+				{"stash", "list", "--format=%H%x00%gd%x00%gs%x00%ct"},
+			},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := [][]string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = append(gotArgs, args)
+			return &mockRunner{}
+		}
+		entry, err := (&Repository{}).StashPush(c.Opts)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || entry != nil || err != nil {
+			t.Errorf("%s\nexpected : %v\ngot      : %v, %v, %v", c.CaseName, c.ExpectArgs, gotArgs, entry, err)
+		}
+	}
+}
+
+func TestParseStashList(t *testing.T) {
+	out := "4bb136f59820de18d6d73623efd8a6cca30af742\x00stash@{0}\x00On main: my message\x001700000000\n" +
+		"296fa69cbdd5254e2e2c8f4e23ed59d1542aee02\x00stash@{1}\x00WIP on feature/x: cab79d5 subject\x001600000000\n"
+	expect := []StashEntry{
+		{Index: 0, Hash: "4bb136f59820de18d6d73623efd8a6cca30af742", Message: "my message", Branch: "main", Date: time.Unix(1700000000, 0)},
+		{Index: 1, Hash: "296fa69cbdd5254e2e2c8f4e23ed59d1542aee02", Message: "cab79d5 subject", Branch: "feature/x", Date: time.Unix(1600000000, 0)},
+	}
+	if got := parseStashList(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
+
+func TestStashEntryRequired(t *testing.T) {
+	execCommand = func(args ...string) runner {
+		return &mockRunner{}
+	}
+	r := &Repository{}
+	if _, err := r.StashShow(StashEntry{}); !equalErr(errors.New("go-git: StashShow() no stash entry specified"), err) {
+		t.Errorf("StashShow: unexpected error %v", err)
+	}
+	if _, err := r.StashApply(StashEntry{}, false); !equalErr(errors.New("go-git: StashApply() no stash entry specified"), err) {
+		t.Errorf("StashApply: unexpected error %v", err)
+	}
+	if _, err := r.StashPop(StashEntry{}, false); !equalErr(errors.New("go-git: StashPop() no stash entry specified"), err) {
+		t.Errorf("StashPop: unexpected error %v", err)
+	}
+	if err := r.StashDrop(StashEntry{}); !equalErr(errors.New("go-git: StashDrop() no stash entry specified"), err) {
+		t.Errorf("StashDrop: unexpected error %v", err)
+	}
+	if err := r.StashDrop(StashEntry{Hash: "4bb136f"}); !equalErr(errors.New("go-git: stash entry 4bb136f not found"), err) {
+		t.Errorf("StashDrop: unexpected error %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Operation is a multi-step operation that can be in progress in a repository.
+type Operation int
+
+const (
+	// OperationNone means no operation is in progress.
+	OperationNone Operation = iota
+	// OperationMerge means a merge stopped before committing.
+	OperationMerge
+	// OperationRebase means a rebase using the merge backend, the default and the one used by interactive rebases, is in progress.
+	OperationRebase
+	// OperationRebaseApply means a rebase using the apply backend is in progress.
+	OperationRebaseApply
+	// OperationApplyMailbox means git am is in progress.
+	OperationApplyMailbox
+	// OperationCherryPick means a cherry-pick is in progress.
+	OperationCherryPick
+	// OperationRevert means a revert is in progress.
+	OperationRevert
+)
+
+// RepositoryState describes what a repository is in the middle of.
+type RepositoryState struct {
+	Operation Operation
+	Bisecting bool   // Bisecting is true during a git bisect session, which may overlap with other operations.
+	Detached  bool   // Detached is true when HEAD points directly at a commit.
+	Head      string // Head is the commit HEAD points at, or "" on an unborn branch.
+	// Branch is the checked out branch. During a rebase, which detaches HEAD,
+	// it is the branch being rebased. It is "" when HEAD is detached otherwise.
+	Branch string
+
+	// The following describe a rebase or git am in progress.
+	Onto  string // Onto is the commit being rebased onto.
+	Step  int    // Step is the number of the step being applied, starting at 1.
+	Total int    // Total is the number of steps.
+}
+
+// State inspects the repository's git directory and HEAD to work out what it is in the middle of.
+func (r *Repository) State() (*RepositoryState, error) {
+	gitDir, err := r.gitDir()
+	if err != nil {
+		return nil, err
+	}
+	path := func(name ...string) string { return filepath.Join(append([]string{gitDir}, name...)...) }
+	exists := func(name ...string) bool {
+		_, err := os.Stat(path(name...))
+		return err == nil
+	}
+	read := func(name ...string) string {
+		b, _ := os.ReadFile(path(name...))
+		return strings.TrimSpace(string(b))
+	}
+	atoi := func(name ...string) int {
+		n, _ := strconv.Atoi(read(name...))
+		return n
+	}
+
+	s := &RepositoryState{Bisecting: exists("BISECT_LOG")}
+	head := read("HEAD")
+	if ref, ok := strings.CutPrefix(head, "ref: "); ok {
+		s.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	} else {
+		s.Detached = true
+	}
+	stdout, _, err := r.run("rev-parse", "--quiet", "--verify", "HEAD")
+	if err == nil {
+		s.Head = strings.TrimSpace(stdout)
+	}
+
+	switch {
+	case exists("rebase-merge"):
+		s.Operation = OperationRebase
+		s.Branch = strings.TrimPrefix(read("rebase-merge", "head-name"), "refs/heads/")
+		s.Onto = read("rebase-merge", "onto")
+		s.Step = atoi("rebase-merge", "msgnum")
+		s.Total = atoi("rebase-merge", "end")
+	case exists("rebase-apply"):
+		s.Operation = OperationRebaseApply
+		if exists("rebase-apply", "applying") {
+			s.Operation = OperationApplyMailbox
+		} else {
+			s.Branch = strings.TrimPrefix(read("rebase-apply", "head-name"), "refs/heads/")
+			s.Onto = read("rebase-apply", "onto")
+		}
+		s.Step = atoi("rebase-apply", "next")
+		s.Total = atoi("rebase-apply", "last")
+	case exists("CHERRY_PICK_HEAD"):
+		s.Operation = OperationCherryPick
+	case exists("REVERT_HEAD"):
+		s.Operation = OperationRevert
+	case exists("MERGE_HEAD"):
+		s.Operation = OperationMerge
+	case exists("sequencer", "todo"):
+		// A multi-commit cherry-pick or revert stopped for a reason other than conflicts.
+		s.Operation = OperationCherryPick
+		if strings.HasPrefix(read("sequencer", "todo"), "revert") {
+			s.Operation = OperationRevert
+		}
+	}
+	// A rebase started from a detached HEAD records "detached HEAD" as its head-name.
+	if s.Branch == "HEAD" || s.Branch == "detached HEAD" {
+		s.Branch = ""
+	}
+	return s, nil
+}
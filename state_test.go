@@ -0,0 +1,142 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	cases := []struct {
+		CaseName    string
+		Files       map[string]string
+		ExpectState RepositoryState
+	}{
+		{
+			CaseName:    "Nothing in progress",
+			ExpectState: RepositoryState{Branch: "main"},
+		},
+		{
+			CaseName:    "Detached HEAD while bisecting",
+			Files:       map[string]string{"HEAD": "9fceb02d0ae598e95dc970b74767f19372d61af8\n", "BISECT_LOG": ""},
+			ExpectState: RepositoryState{Bisecting: true, Detached: true, Head: "9fceb02d0ae598e95dc970b74767f19372d61af8"},
+		},
+		{
+			CaseName: "Merge backend rebase",
+			Files: map[string]string{
+				"HEAD":                     "9fceb02d0ae598e95dc970b74767f19372d61af8\n",
+				"rebase-merge/head-name":   "refs/heads/feature\n",
+				"rebase-merge/onto":        "61780798228d17af2d34fce4cfbdf35556832472\n",
+				"rebase-merge/msgnum":      "2\n",
+				"rebase-merge/end":         "5\n",
+				"rebase-merge/interactive": "",
+			},
+			ExpectState: RepositoryState{
+				Operation: OperationRebase,
+				Detached:  true,
+				Head:      "9fceb02d0ae598e95dc970b74767f19372d61af8",
+				Branch:    "feature",
+				Onto:      "61780798228d17af2d34fce4cfbdf35556832472",
+				Step:      2,
+				Total:     5,
+			},
+		},
+		{
+			CaseName: "Apply backend rebase",
+			Files: map[string]string{
+				"rebase-apply/head-name": "refs/heads/feature\n",
+				"rebase-apply/onto":      "61780798228d17af2d34fce4cfbdf35556832472\n",
+				"rebase-apply/next":      "1\n",
+				"rebase-apply/last":      "3\n",
+				"rebase-apply/rebasing":  "",
+			},
+			ExpectState: RepositoryState{
+				Operation: OperationRebaseApply,
+				Branch:    "feature",
+				Onto:      "61780798228d17af2d34fce4cfbdf35556832472",
+				Step:      1,
+				Total:     3,
+			},
+		},
+		{
+			CaseName:    "Merge",
+			Files:       map[string]string{"MERGE_HEAD": "61780798228d17af2d34fce4cfbdf35556832472\n"},
+			ExpectState: RepositoryState{Operation: OperationMerge, Branch: "main"},
+		},
+		{
+			CaseName:    "Cherry-pick",
+			Files:       map[string]string{"CHERRY_PICK_HEAD": "61780798228d17af2d34fce4cfbdf35556832472\n"},
+			ExpectState: RepositoryState{Operation: OperationCherryPick, Branch: "main"},
+		},
+		{
+			CaseName:    "Revert stopped between commits",
+			Files:       map[string]string{"sequencer/todo": "revert 6178079 subject\n"},
+			ExpectState: RepositoryState{Operation: OperationRevert, Branch: "main"},
+		},
+	}
+	for _, c := range cases {
+		r := testRepository(t, "")
+		for name, content := range c.Files {
+			path := filepath.Join(r.Dir, ".git", name)
+			os.MkdirAll(filepath.Dir(path), 0755)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := r.State()
+		if err != nil || !reflect.DeepEqual(c.ExpectState, *got) {
+			t.Errorf("%s\nexpected : %+v\ngot      : %+v, %v", c.CaseName, c.ExpectState, got, err)
+		}
+	}
+}
+
+func TestStateStoppedRebase(t *testing.T) {
+	cases := []struct {
+		CaseName     string
+		Checkout     []string
+		ExpectBranch string
+	}{
+		{CaseName: "Branch", Checkout: []string{"feature"}, ExpectBranch: "feature"},
+		{CaseName: "Detached HEAD", Checkout: []string{"--detach", "feature"}, ExpectBranch: ""},
+	}
+	for _, c := range cases {
+		r := testRepository(t, "")
+		testWriteFile(t, r, "f", "base\n")
+		testCommit(t, r, "base")
+		r.run("checkout", "-b", "feature")
+		testWriteFile(t, r, "f", "feature\n")
+		testCommit(t, r, "feature one")
+		testWriteFile(t, r, "g", "feature\n")
+		testCommit(t, r, "feature two")
+		r.run("checkout", "main")
+		testWriteFile(t, r, "f", "main\n")
+		main := testCommit(t, r, "main")
+		if _, _, err := r.run(append([]string{"checkout"}, c.Checkout...)...); err != nil {
+			t.Fatal(err)
+		}
+
+		conflict, err := r.Rebase("main", "", nil)
+		if err != nil || conflict == nil {
+			t.Fatalf("%s: expected the rebase to stop on a conflict, got %v, %v", c.CaseName, conflict, err)
+		}
+		got, err := r.State()
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout, _, _ := r.run("rev-parse", "HEAD")
+		expect := RepositoryState{
+			Operation: OperationRebase,
+			Detached:  true,
+			Head:      strings.TrimSpace(stdout),
+			Branch:    c.ExpectBranch,
+			Onto:      main,
+			Step:      1,
+			Total:     2,
+		}
+		if !reflect.DeepEqual(expect, *got) {
+			t.Errorf("%s\nexpected : %+v\ngot      : %+v", c.CaseName, expect, *got)
+		}
+	}
+}
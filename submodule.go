@@ -0,0 +1,318 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Submodule is an entry of the .gitmodules file.
+type Submodule struct {
+	Name    string // Name identifies the submodule, and defaults to its path.
+	Path    string // Path is where the submodule is checked out, relative to the superproject.
+	URL     string // URL is the repository the submodule is cloned from.
+	Branch  string // Branch is the remote branch tracked by updates with Remote set.
+	Update  string // Update is the update strategy, e.g. "checkout", "rebase", "merge" or "none".
+	Ignore  string // Ignore controls when the submodule is reported as modified, e.g. "dirty" or "all".
+	Shallow bool   // Shallow recommends cloning the submodule with a depth of 1.
+}
+
+// Submodules parses the .gitmodules file at the top level of the working tree. It returns no
+// entries if the file does not exist.
+func (r *Repository) Submodules() ([]Submodule, error) {
+	stdout, _, err := r.run("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	gitmodules := filepath.Join(strings.TrimSpace(stdout), ".gitmodules")
+	if _, err := os.Stat(gitmodules); errors.Is(err, os.ErrNotExist) {
+		return []Submodule{}, nil
+	}
+	stdout, _, err = r.run("config", "--file", gitmodules, "--null", "--list")
+	if err != nil {
+		return nil, err
+	}
+	return parseGitmodules(stdout), nil
+}
+
+// parseGitmodules parses the output of git config --null --list, where each key is
+// followed by a newline, its value and a NUL, into submodules in the order they appear.
+func parseGitmodules(out string) []Submodule {
+	submodules := []Submodule{}
+	index := map[string]int{}
+	for _, entry := range splitNul(out) {
+		key, value, _ := strings.Cut(entry, "\n")
+		if !strings.HasPrefix(key, "submodule.") {
+			continue
+		}
+		// Names may contain dots, so the variable is whatever follows the last one.
+		dot := strings.LastIndex(key, ".")
+		if dot < len("submodule.") {
+			continue
+		}
+		name, variable := key[len("submodule."):dot], key[dot+1:]
+		i, ok := index[name]
+		if !ok {
+			i = len(submodules)
+			index[name] = i
+			submodules = append(submodules, Submodule{Name: name})
+		}
+		s := &submodules[i]
+		switch variable {
+		case "path":
+			s.Path = value
+		case "url":
+			s.URL = value
+		case "branch":
+			s.Branch = value
+		case "update":
+			s.Update = value
+		case "ignore":
+			s.Ignore = value
+		case "shallow":
+			s.Shallow = value == "true"
+		}
+	}
+	return submodules
+}
+
+// SubmoduleAddOptions configures SubmoduleAdd.
+type SubmoduleAddOptions struct {
+	Name   string // Name names the submodule instead of its path (--name).
+	Branch string // Branch is the remote branch to check out and track (--branch).
+	Depth  int    // Depth creates a shallow clone with this many commits (--depth).
+	Force  bool   // Force adds the submodule even if its path is ignored (--force).
+}
+
+// SubmoduleAdd clones url into path and records it as a submodule. If path is empty it is
+// derived from url. opts may be nil.
+func (r *Repository) SubmoduleAdd(url, path string, opts *SubmoduleAddOptions) error {
+	if url == "" {
+		return errors.New("go-git: SubmoduleAdd() no url specified")
+	}
+	if opts == nil {
+		opts = &SubmoduleAddOptions{}
+	}
+	if opts.Depth < 0 {
+		return errors.New("go-git: SubmoduleAdd() depth must not be negative")
+	}
+	args := []string{"submodule", "add"}
+	if opts.Name != "" {
+		args = append(args, "--name", opts.Name)
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	args = append(args, "--", url)
+	if path != "" {
+		args = append(args, path)
+	}
+	c := r.withSubmoduleProgress(r.command(args...))
+	cleanup, err := r.withTransport(c, url)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	_, _, err = c.run()
+	return err
+}
+
+// withSubmoduleProgress is withProgress for git submodule, which takes --progress after its own subcommand.
+func (r *Repository) withSubmoduleProgress(c *command) *command {
+	if r.Progress != nil {
+		args := []string{c.args[0], c.args[1], "--progress"}
+		c.args = append(args, c.args[2:]...)
+		c.progress = r.Progress
+	}
+	return c
+}
+
+// SubmoduleInit copies the urls of the submodules at paths, or of all submodules if none
+// are given, from .gitmodules into the repository configuration.
+func (r *Repository) SubmoduleInit(paths ...string) error {
+	_, _, err := r.run(append([]string{"submodule", "init", "--"}, paths...)...)
+	return err
+}
+
+// SubmoduleUpdateOptions configures SubmoduleUpdate.
+type SubmoduleUpdateOptions struct {
+	Init      bool // Init initializes submodules that are not yet initialized (--init).
+	Remote    bool // Remote updates to the tip of the tracked remote branch instead of the recorded commit (--remote).
+	Recursive bool // Recursive also updates nested submodules (--recursive).
+	Depth     int  // Depth creates shallow clones with this many commits (--depth).
+	Jobs      int  // Jobs is the number of submodules fetched in parallel (--jobs).
+	Force     bool // Force discards local changes in the submodules (--force).
+}
+
+// SubmoduleUpdate clones missing submodules and checks out the recorded commit in the submodules at paths,
+// or in all submodules if none are given. opts may be nil.
+//
+// The submodules may come from different hosts, so the repository's Credentials are not
+// consulted; its SSH options are used for every submodule.
+func (r *Repository) SubmoduleUpdate(opts *SubmoduleUpdateOptions, paths ...string) error {
+	if opts == nil {
+		opts = &SubmoduleUpdateOptions{}
+	}
+	if opts.Depth < 0 {
+		return errors.New("go-git: SubmoduleUpdate() depth must not be negative")
+	}
+	if opts.Jobs < 0 {
+		return errors.New("go-git: SubmoduleUpdate() jobs must not be negative")
+	}
+	args := []string{"submodule", "update"}
+	if opts.Init {
+		args = append(args, "--init")
+	}
+	if opts.Remote {
+		args = append(args, "--remote")
+	}
+	if opts.Recursive {
+		args = append(args, "--recursive")
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Jobs > 0 {
+		args = append(args, "--jobs", strconv.Itoa(opts.Jobs))
+	}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	args = append(args, "--")
+	c := r.withSubmoduleProgress(r.command(append(args, paths...)...))
+	cleanup, err := r.withSSH(c)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	_, _, err = c.run()
+	return err
+}
+
+// SubmoduleState describes how the checked out commit of a submodule relates to the superproject.
+type SubmoduleState int
+
+const (
+	// SubmoduleCurrent means the submodule has the commit recorded in the superproject checked out.
+	SubmoduleCurrent SubmoduleState = iota
+	// SubmoduleUninitialized means the submodule is not initialized.
+	SubmoduleUninitialized
+	// SubmoduleModified means the submodule has a different commit checked out than recorded.
+	SubmoduleModified
+	// SubmoduleConflict means the submodule has merge conflicts in the superproject.
+	SubmoduleConflict
+)
+
+// SubmoduleStatus reports the state of a submodule.
+type SubmoduleStatus struct {
+	Path     string         // Path is relative to the superproject.
+	Commit   string         // Commit is the checked out commit, or the recorded one when uninitialized.
+	Describe string         // Describe is the git describe output for Commit, if available.
+	State    SubmoduleState // State compares the checked out commit with the recorded one.
+	Dirty    bool           // Dirty is true when the submodule has uncommitted changes or untracked files.
+}
+
+// SubmoduleStatus reports the state of the submodules at paths, or of all submodules if none are given.
+// recursive includes nested submodules.
+func (r *Repository) SubmoduleStatus(recursive bool, paths ...string) ([]SubmoduleStatus, error) {
+	args := []string{"submodule", "status"}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	args = append(args, "--")
+	stdout, _, err := r.run(append(args, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+	statuses := parseSubmoduleStatus(stdout)
+	for i, s := range statuses {
+		if s.State == SubmoduleUninitialized {
+			continue
+		}
+		sub := *r
+		sub.Dir = filepath.Join(r.Dir, s.Path)
+		stdout, _, err := sub.run("status", "--porcelain", "-z", "--ignore-submodules=none")
+		if err != nil {
+			return nil, err
+		}
+		statuses[i].Dirty = stdout != ""
+	}
+	return statuses, nil
+}
+
+// parseSubmoduleStatus parses the output of git submodule status, one line per submodule
+// holding a state character, the commit, the path and the optional describe output in parentheses.
+func parseSubmoduleStatus(out string) []SubmoduleStatus {
+	statuses := []SubmoduleStatus{}
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		s := SubmoduleStatus{}
+		switch line[0] {
+		case '-':
+			s.State = SubmoduleUninitialized
+		case '+':
+			s.State = SubmoduleModified
+		case 'U':
+			s.State = SubmoduleConflict
+		}
+		s.Commit, s.Path, _ = strings.Cut(line[1:], " ")
+		if i := strings.LastIndex(s.Path, " ("); i >= 0 && strings.HasSuffix(s.Path, ")") {
+			s.Describe = s.Path[i+2 : len(s.Path)-1]
+			s.Path = s.Path[:i]
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// SubmoduleSync updates the configured urls of the submodules at paths, or of all submodules if none
+// are given, to match .gitmodules. recursive includes nested submodules.
+func (r *Repository) SubmoduleSync(recursive bool, paths ...string) error {
+	args := []string{"submodule", "sync"}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	args = append(args, "--")
+	_, _, err := r.run(append(args, paths...)...)
+	return err
+}
+
+// SubmoduleDeinit unregisters the submodules at paths, or all submodules if none are given,
+// and removes their working trees. force removes working trees with local modifications.
+func (r *Repository) SubmoduleDeinit(force bool, paths ...string) error {
+	args := []string{"submodule", "deinit"}
+	if force {
+		args = append(args, "--force")
+	}
+	if len(paths) == 0 {
+		args = append(args, "--all")
+	}
+	args = append(args, "--")
+	_, _, err := r.run(append(args, paths...)...)
+	return err
+}
+
+// SubmoduleForeach runs the shell command in each checked out submodule and returns the standard
+// output, where git precedes the output of each submodule with an "Entering '<path>'" line.
+// recursive includes nested submodules. git stops at the first submodule for which command fails.
+func (r *Repository) SubmoduleForeach(recursive bool, command string) (string, error) {
+	if command == "" {
+		return "", errors.New("go-git: SubmoduleForeach() no command specified")
+	}
+	args := []string{"submodule", "foreach"}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	stdout, _, err := r.run(append(args, command)...)
+	return stdout, err
+}
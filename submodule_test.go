@@ -0,0 +1,159 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSubmoduleArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Call       func(r *Repository) error
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "Add without url",
+			Call:       func(r *Repository) error { return r.SubmoduleAdd("", "lib", nil) },
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: SubmoduleAdd() no url specified"),
+		},
+		{
+			CaseName: "Add",
+			Call: func(r *Repository) error {
+				return r.SubmoduleAdd("https://example.com/lib.git", "vendor/lib", &SubmoduleAddOptions{Name: "lib", Branch: "main", Depth: 1})
+			},
+			ExpectArgs: []string{"submodule", "add", "--name", "lib", "--branch", "main", "--depth", "1", "--", "https://example.com/lib.git", "vendor/lib"},
+		},
+		{
+			CaseName: "Update",
+			Call: func(r *Repository) error {
+				return r.SubmoduleUpdate(&SubmoduleUpdateOptions{Init: true, Remote: true, Recursive: true, Depth: 1, Jobs: 4}, "lib")
+			},
+			ExpectArgs: []string{"submodule", "update", "--init", "--remote", "--recursive", "--depth", "1", "--jobs", "4", "--", "lib"},
+		},
+		{
+			CaseName:   "Update with negative jobs",
+			Call:       func(r *Repository) error { return r.SubmoduleUpdate(&SubmoduleUpdateOptions{Jobs: -1}) },
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: SubmoduleUpdate() jobs must not be negative"),
+		},
+		{
+			CaseName:   "Sync",
+			Call:       func(r *Repository) error { return r.SubmoduleSync(true) },
+			ExpectArgs: []string{"submodule", "sync", "--recursive", "--"},
+		},
+		{
+			CaseName:   "Deinit all",
+			Call:       func(r *Repository) error { return r.SubmoduleDeinit(true) },
+			ExpectArgs: []string{"submodule", "deinit", "--force", "--all", "--"},
+		},
+		{
+			CaseName:   "Deinit path",
+			Call:       func(r *Repository) error { return r.SubmoduleDeinit(false, "lib") },
+			ExpectArgs: []string{"submodule", "deinit", "--", "lib"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := c.Call(&Repository{})
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseGitmodules(t *testing.T) {
+	out := "submodule.lib.path\nvendor/lib\x00submodule.lib.url\nhttps://example.com/lib.git\x00" +
+		"submodule.tools.v2.path\ntools\x00submodule.tools.v2.url\n../tools.git\x00submodule.tools.v2.branch\nstable\x00" +
+		"submodule.tools.v2.shallow\ntrue\x00submodule.lib.update\nrebase\x00"
+	expect := []Submodule{
+		{Name: "lib", Path: "vendor/lib", URL: "https://example.com/lib.git", Update: "rebase"},
+		{Name: "tools.v2", Path: "tools", URL: "../tools.git", Branch: "stable", Shallow: true},
+	}
+	if got := parseGitmodules(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
+
+func TestParseSubmoduleStatus(t *testing.T) {
+	out := " aaaa vendor/lib (v1.0.0)\n-bbbb tools\n+cccc my lib (heads/main)\nUdddd conflicted\n"
+	expect := []SubmoduleStatus{
+		{Path: "vendor/lib", Commit: "aaaa", Describe: "v1.0.0"},
+		{Path: "tools", Commit: "bbbb", State: SubmoduleUninitialized},
+		{Path: "my lib", Commit: "cccc", Describe: "heads/main", State: SubmoduleModified},
+		{Path: "conflicted", Commit: "dddd", State: SubmoduleConflict},
+	}
+	if got := parseSubmoduleStatus(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
+
+func TestSubmodules(t *testing.T) {
+	// Submodules are cloned from local paths, which git refuses by default.
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+	dir := t.TempDir()
+	lib := testRepository(t, filepath.Join(dir, "lib"))
+	testCommit(t, lib, "first")
+	r := testRepository(t, filepath.Join(dir, "repo"))
+	if got, err := r.Submodules(); err != nil || len(got) != 0 {
+		t.Errorf("expected no submodules, got %+v, %v", got, err)
+	}
+
+	if err := r.SubmoduleAdd(lib.Dir, "vendor/lib", &SubmoduleAddOptions{Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	submodules, err := r.Submodules()
+	expect := []Submodule{{Name: "vendor/lib", Path: "vendor/lib", URL: lib.Dir, Branch: "main"}}
+	if err != nil || !reflect.DeepEqual(expect, submodules) {
+		t.Errorf("expected : %+v\ngot      : %+v, %v", expect, submodules, err)
+	}
+
+	sub := &Repository{Dir: filepath.Join(r.Dir, "vendor")}
+	if submodules, err = sub.Submodules(); err != nil || !reflect.DeepEqual(expect, submodules) {
+		t.Errorf("from a subdirectory\nexpected : %+v\ngot      : %+v, %v", expect, submodules, err)
+	}
+
+	statuses, err := r.SubmoduleStatus(false)
+	if err != nil || len(statuses) != 1 || statuses[0].State != SubmoduleCurrent || statuses[0].Dirty {
+		t.Fatalf("expected a clean submodule, got %+v, %v", statuses, err)
+	}
+	os.WriteFile(filepath.Join(r.Dir, "vendor/lib/new.txt"), []byte("new\n"), 0o644)
+	if statuses, _ = r.SubmoduleStatus(false); !statuses[0].Dirty {
+		t.Errorf("expected a dirty submodule, got %+v", statuses)
+	}
+
+	out, err := r.SubmoduleForeach(false, "git rev-parse --abbrev-ref HEAD")
+	if err != nil || out != "Entering 'vendor/lib'\nmain\n" {
+		t.Errorf("unexpected foreach output %q, %v", out, err)
+	}
+
+	if err := r.SubmoduleDeinit(true); err != nil {
+		t.Fatal(err)
+	}
+	if statuses, _ = r.SubmoduleStatus(false); statuses[0].State != SubmoduleUninitialized {
+		t.Errorf("expected an uninitialized submodule, got %+v", statuses)
+	}
+	if err := r.SubmoduleUpdate(&SubmoduleUpdateOptions{Init: true}); err != nil {
+		t.Fatal(err)
+	}
+	if statuses, _ = r.SubmoduleStatus(false); statuses[0].State != SubmoduleCurrent {
+		t.Errorf("expected an updated submodule, got %+v", statuses)
+	}
+	if err := r.SubmoduleSync(true); err != nil {
+		t.Fatal(err)
+	}
+}
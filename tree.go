@@ -0,0 +1,98 @@
+package git
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ShowFile returns the content of the file at path as of revision rev, e.g. "v1.0.0".
+// path is relative to the root of the tree.
+func (r *Repository) ShowFile(rev, path string) ([]byte, error) {
+	if rev == "" || path == "" {
+		return nil, errors.New("go-git: ShowFile() revision and path must be specified")
+	}
+	stdout, _, err := r.run("cat-file", "blob", rev+":"+path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(stdout), nil
+}
+
+// OpenFile is like ShowFile but streams the content instead of reading it into memory.
+// The caller must close the reader. Errors from git, such as a missing path, are returned
+// by Read once the content is exhausted.
+func (r *Repository) OpenFile(rev, path string) (io.ReadCloser, error) {
+	if rev == "" || path == "" {
+		return nil, errors.New("go-git: OpenFile() revision and path must be specified")
+	}
+	return r.command("cat-file", "blob", rev+":"+path).start()
+}
+
+// BlobSize returns the size in bytes of the file at path as of revision rev. If path is
+// empty rev names the object directly, e.g. by its hash.
+func (r *Repository) BlobSize(rev, path string) (int64, error) {
+	if rev == "" {
+		return 0, errors.New("go-git: BlobSize() no revision specified")
+	}
+	object := rev
+	if path != "" {
+		object += ":" + path
+	}
+	stdout, _, err := r.run("cat-file", "-s", object)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+}
+
+// TreeEntry is an entry of a tree object.
+type TreeEntry struct {
+	Mode string // Mode is the file mode, e.g. ModeRegular or ModeTree.
+	Type string // Type is the object type, "blob", "tree" or "commit" for submodules.
+	Hash string // Hash is the object the entry points at.
+	Size int64  // Size is the size of a blob in bytes, or -1 for other types.
+	Name string // Name is the path of the entry relative to the listed directory.
+}
+
+// ListTree lists the entries of the directory at path as of revision rev, or of the root
+// directory if path is empty. recursive lists the files of subdirectories instead of the
+// subdirectories themselves.
+func (r *Repository) ListTree(rev, path string, recursive bool) ([]TreeEntry, error) {
+	if rev == "" {
+		return nil, errors.New("go-git: ListTree() no revision specified")
+	}
+	args := []string{"ls-tree", "-z", "--long"}
+	if recursive {
+		args = append(args, "-r")
+	}
+	stdout, _, err := r.run(append(args, rev+":"+path)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLsTree(stdout)
+}
+
+// parseLsTree parses the output of git ls-tree -z --long, one NUL terminated entry per object
+// holding the mode, type, hash and padded size, followed by a tab and the name.
+func parseLsTree(out string) ([]TreeEntry, error) {
+	entries := []TreeEntry{}
+	for _, entry := range splitNul(out) {
+		meta, name, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 4 {
+			return nil, errors.New("go-git: unexpected ls-tree entry " + entry)
+		}
+		e := TreeEntry{Mode: fields[0], Type: fields[1], Hash: fields[2], Size: -1, Name: name}
+		if fields[3] != "-" {
+			size, err := strconv.ParseInt(fields[3], 10, 64)
+			if err != nil {
+				return nil, errors.New("go-git: unexpected ls-tree entry " + entry)
+			}
+			e.Size = size
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,160 @@
+package git
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTreeArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Call       func(r *Repository) error
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "Show file",
+			Call:       func(r *Repository) error { _, err := r.ShowFile("v1.0.0", "go.mod"); return err },
+			ExpectArgs: []string{"cat-file", "blob", "v1.0.0:go.mod"},
+		},
+		{
+			CaseName:   "Show file without path",
+			Call:       func(r *Repository) error { _, err := r.ShowFile("v1.0.0", ""); return err },
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: ShowFile() revision and path must be specified"),
+		},
+		{
+			CaseName:   "Open file",
+			Call:       func(r *Repository) error { _, err := r.OpenFile("HEAD", "big.bin"); return err },
+			ExpectArgs: []string{"cat-file", "blob", "HEAD:big.bin"},
+		},
+		{
+			CaseName:   "Blob size without revision",
+			Call:       func(r *Repository) error { _, err := r.BlobSize("", "big.bin"); return err },
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: BlobSize() no revision specified"),
+		},
+		{
+			CaseName:   "List root",
+			Call:       func(r *Repository) error { _, err := r.ListTree("main", "", false); return err },
+			ExpectArgs: []string{"ls-tree", "-z", "--long", "main:"},
+		},
+		{
+			CaseName:   "List directory recursively",
+			Call:       func(r *Repository) error { _, err := r.ListTree("main", "cmd", true); return err },
+			ExpectArgs: []string{"ls-tree", "-z", "--long", "-r", "main:cmd"},
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		gotErr := c.Call(&Repository{})
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseLsTree(t *testing.T) {
+	out := "100644 blob aaaa       6\tREADME.md\x00" +
+		"040000 tree bbbb       -\tcmd\x00" +
+		"160000 commit cccc       -\tvendor/lib\x00" +
+		"100755 blob dddd 1048576\tname with\ttab\x00"
+	expect := []TreeEntry{
+		{Mode: ModeRegular, Type: "blob", Hash: "aaaa", Size: 6, Name: "README.md"},
+		{Mode: ModeTree, Type: "tree", Hash: "bbbb", Size: -1, Name: "cmd"},
+		{Mode: ModeSubmodule, Type: "commit", Hash: "cccc", Size: -1, Name: "vendor/lib"},
+		{Mode: ModeExecutable, Type: "blob", Hash: "dddd", Size: 1048576, Name: "name with\ttab"},
+	}
+	got, err := parseLsTree(out)
+	if err != nil || !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v, %v", expect, got, err)
+	}
+	if _, err := parseLsTree("100644 blob aaaa\tREADME.md\x00"); err == nil {
+		t.Error("expected an error for an entry without size")
+	}
+}
+
+func TestShowFile(t *testing.T) {
+	r := testRepository(t, "")
+	testWriteFile(t, r, "cmd/main.go", "package main\n")
+	testWriteFile(t, r, "data.bin", "\x00\x01\x02\x00")
+	testWriteFile(t, r, "large.txt", strings.Repeat("large\n", 1<<20))
+	testCommit(t, r, "first")
+	if _, _, err := r.run("tag", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	testWriteFile(t, r, "cmd/main.go", "package changed\n")
+
+	content, err := r.ShowFile("v1", "cmd/main.go")
+	if err != nil || string(content) != "package main\n" {
+		t.Errorf("unexpected content %q, %v", content, err)
+	}
+	f, err := r.OpenFile("v1", "data.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err = io.ReadAll(f)
+	f.Close()
+	if err != nil || !reflect.DeepEqual([]byte{0, 1, 2, 0}, content) {
+		t.Errorf("unexpected content %v, %v", content, err)
+	}
+	f, err = r.OpenFile("v1", "missing.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gitErr *Error
+	if _, err := io.ReadAll(f); !errors.As(err, &gitErr) {
+		t.Errorf("expected a git error reading a missing file, got %v", err)
+	}
+	f.Close()
+
+	// Closing before the end must not wait for git to write the rest.
+	f, err = r.OpenFile("v1", "large.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 6)
+	if _, err := io.ReadFull(f, buf); err != nil || string(buf) != "large\n" {
+		t.Errorf("unexpected content %q, %v", buf, err)
+	}
+	f.Close()
+
+	execCommand = func(args ...string) runner { return exec.Command("/nonexistent/git", args...) }
+	if f, err := r.OpenFile("v1", "data.bin"); f != nil || err == nil {
+		t.Errorf("expected no reader when git cannot start, got %v, %v", f, err)
+	}
+	execCommand = func(args ...string) runner { return exec.Command("git", args...) }
+
+	if size, err := r.BlobSize("v1", "cmd/main.go"); err != nil || size != 13 {
+		t.Errorf("unexpected size %v, %v", size, err)
+	}
+	stdout, _, _ := r.run("rev-parse", "v1:data.bin")
+	if size, err := r.BlobSize(strings.TrimSpace(stdout), ""); err != nil || size != 4 {
+		t.Errorf("unexpected size %v, %v", size, err)
+	}
+
+	entries, err := r.ListTree("v1", "", false)
+	if err != nil || len(entries) != 3 || entries[0].Name != "cmd" || entries[0].Type != "tree" || entries[1].Size != 4 {
+		t.Errorf("unexpected root entries %+v, %v", entries, err)
+	}
+	entries, err = r.ListTree("v1", "", true)
+	if err != nil || len(entries) != 3 || entries[0].Name != "cmd/main.go" || entries[0].Size != 13 {
+		t.Errorf("unexpected recursive entries %+v, %v", entries, err)
+	}
+	entries, err = r.ListTree("v1", "cmd", false)
+	if err != nil || len(entries) != 1 || entries[0].Name != "main.go" || entries[0].Mode != ModeRegular {
+		t.Errorf("unexpected cmd entries %+v, %v", entries, err)
+	}
+}
@@ -0,0 +1,198 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree describes a working tree attached to the repository.
+type Worktree struct {
+	Path        string      // Path is the absolute path of the working tree.
+	Head        string      // Head is the checked out commit, or "" for a bare repository or unborn branch.
+	Branch      string      // Branch is the full name of the checked out branch, or "" when detached.
+	Bare        bool        // Bare is true for the main worktree of a bare repository.
+	Detached    bool        // Detached is true when HEAD points directly at a commit.
+	Locked      bool        // Locked is true when the worktree is protected from pruning, moving and removal.
+	LockReason  string      // LockReason is the reason given when locking, if any.
+	Prunable    bool        // Prunable is true when the worktree can be pruned, e.g. because its directory is gone.
+	PruneReason string      // PruneReason explains why the worktree is prunable.
+	Repository  *Repository // Repository is a handle bound to the worktree.
+}
+
+// WorktreeAddOptions configures WorktreeAdd.
+type WorktreeAddOptions struct {
+	NewBranch  string // NewBranch creates a branch with this name at the commit and checks it out (-b).
+	Detach     bool   // Detach checks out the commit with a detached HEAD (--detach).
+	NoCheckout bool   // NoCheckout creates the worktree without populating it (--no-checkout).
+	Force      bool   // Force allows checking out a branch that is checked out elsewhere (--force).
+	Lock       bool   // Lock locks the worktree once it is created (--lock).
+	LockReason string // LockReason explains the lock (--reason).
+}
+
+// WorktreeAdd creates a worktree at path checking out commitish and returns a handle bound to it.
+// A relative path is taken relative to the repository. commitish may be empty to check out
+// a new branch named after the last component of path. opts may be nil.
+func (r *Repository) WorktreeAdd(path, commitish string, opts *WorktreeAddOptions) (*Repository, error) {
+	if path == "" {
+		return nil, errors.New("go-git: WorktreeAdd() no path specified")
+	}
+	if opts == nil {
+		opts = &WorktreeAddOptions{}
+	}
+	if opts.NewBranch != "" && opts.Detach {
+		return nil, errors.New("go-git: WorktreeAdd() NewBranch and Detach are mutually exclusive")
+	}
+	if opts.LockReason != "" && !opts.Lock {
+		return nil, errors.New("go-git: WorktreeAdd() LockReason requires Lock")
+	}
+	args := []string{"worktree", "add"}
+	if opts.NewBranch != "" {
+		args = append(args, "-b", opts.NewBranch)
+	}
+	if opts.Detach {
+		args = append(args, "--detach")
+	}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	if opts.Lock {
+		args = append(args, "--lock")
+		if opts.LockReason != "" {
+			args = append(args, "--reason", opts.LockReason)
+		}
+	}
+	args = append(args, "--", path)
+	if commitish != "" {
+		args = append(args, commitish)
+	}
+	if _, _, err := r.run(args...); err != nil {
+		return nil, err
+	}
+	return r.worktreeRepository(path), nil
+}
+
+// worktreeRepository returns a handle for the worktree at path sharing r's settings.
+func (r *Repository) worktreeRepository(path string) *Repository {
+	if !filepath.IsAbs(path) && r.Dir != "" {
+		path = filepath.Join(r.Dir, path)
+	}
+	w := *r
+	w.Dir = path
+	return &w
+}
+
+// Worktrees lists the main worktree followed by the linked worktrees.
+func (r *Repository) Worktrees() ([]Worktree, error) {
+	stdout, _, err := r.run("worktree", "list", "--porcelain", "-z")
+	if err != nil {
+		return nil, err
+	}
+	worktrees := parseWorktreeList(stdout)
+	for i := range worktrees {
+		worktrees[i].Repository = r.worktreeRepository(worktrees[i].Path)
+	}
+	return worktrees, nil
+}
+
+// parseWorktreeList parses the output of git worktree list --porcelain -z, where each attribute
+// is terminated by a NUL and each worktree by an extra NUL.
+func parseWorktreeList(out string) []Worktree {
+	worktrees := []Worktree{}
+	var w *Worktree
+	for _, line := range strings.Split(out, "\x00") {
+		if line == "" {
+			w = nil
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		if key == "worktree" {
+			worktrees = append(worktrees, Worktree{Path: value})
+			w = &worktrees[len(worktrees)-1]
+			continue
+		}
+		if w == nil {
+			continue
+		}
+		switch key {
+		case "HEAD":
+			w.Head = value
+		case "branch":
+			w.Branch = value
+		case "bare":
+			w.Bare = true
+		case "detached":
+			w.Detached = true
+		case "locked":
+			w.Locked = true
+			w.LockReason = value
+		case "prunable":
+			w.Prunable = true
+			w.PruneReason = value
+		}
+	}
+	return worktrees
+}
+
+// WorktreeLock protects the worktree at path from being pruned, moved or removed.
+// reason may be empty.
+func (r *Repository) WorktreeLock(path, reason string) error {
+	if path == "" {
+		return errors.New("go-git: WorktreeLock() no path specified")
+	}
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	_, _, err := r.run(append(args, "--", path)...)
+	return err
+}
+
+// WorktreeUnlock removes the lock from the worktree at path.
+func (r *Repository) WorktreeUnlock(path string) error {
+	if path == "" {
+		return errors.New("go-git: WorktreeUnlock() no path specified")
+	}
+	_, _, err := r.run("worktree", "unlock", "--", path)
+	return err
+}
+
+// WorktreeMove moves the worktree at path to newPath and returns a handle bound to its new location.
+// force allows moving a locked worktree.
+func (r *Repository) WorktreeMove(path, newPath string, force bool) (*Repository, error) {
+	if path == "" || newPath == "" {
+		return nil, errors.New("go-git: WorktreeMove() no path specified")
+	}
+	args := []string{"worktree", "move"}
+	if force {
+		// A single --force is not enough to move a locked worktree.
+		args = append(args, "--force", "--force")
+	}
+	if _, _, err := r.run(append(args, "--", path, newPath)...); err != nil {
+		return nil, err
+	}
+	return r.worktreeRepository(newPath), nil
+}
+
+// WorktreeRemove deletes the worktree at path. force removes it even if it has local
+// modifications or untracked files, or is locked.
+func (r *Repository) WorktreeRemove(path string, force bool) error {
+	if path == "" {
+		return errors.New("go-git: WorktreeRemove() no path specified")
+	}
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force", "--force")
+	}
+	_, _, err := r.run(append(args, "--", path)...)
+	return err
+}
+
+// WorktreePrune removes the administrative files of worktrees whose directories are gone.
+func (r *Repository) WorktreePrune() error {
+	_, _, err := r.run("worktree", "prune")
+	return err
+}
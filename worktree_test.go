@@ -0,0 +1,145 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWorktreeAddArguments(t *testing.T) {
+	cases := []struct {
+		CaseName   string
+		Path       string
+		Commitish  string
+		Opts       *WorktreeAddOptions
+		ExpectArgs []string
+		ExpectErr  error
+	}{
+		{
+			CaseName:   "No path",
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: WorktreeAdd() no path specified"),
+		},
+		{
+			CaseName:   "Existing branch",
+			Path:       "../build-main",
+			Commitish:  "main",
+			ExpectArgs: []string{"worktree", "add", "--", "../build-main", "main"},
+		},
+		{
+			CaseName:   "New branch",
+			Path:       "../feature",
+			Commitish:  "origin/feature",
+			Opts:       &WorktreeAddOptions{NewBranch: "feature", NoCheckout: true, Lock: true, LockReason: "build"},
+			ExpectArgs: []string{"worktree", "add", "-b", "feature", "--no-checkout", "--lock", "--reason", "build", "--", "../feature", "origin/feature"},
+		},
+		{
+			CaseName:   "Detach",
+			Path:       "../v1",
+			Commitish:  "v1.0.0",
+			Opts:       &WorktreeAddOptions{Detach: true, Force: true},
+			ExpectArgs: []string{"worktree", "add", "--detach", "--force", "--", "../v1", "v1.0.0"},
+		},
+		{
+			CaseName:   "New branch and detach",
+			Path:       "../v1",
+			Opts:       &WorktreeAddOptions{NewBranch: "v1", Detach: true},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: WorktreeAdd() NewBranch and Detach are mutually exclusive"),
+		},
+		{
+			CaseName:   "Reason without lock",
+			Path:       "../v1",
+			Opts:       &WorktreeAddOptions{LockReason: "build"},
+			ExpectArgs: []string{},
+			ExpectErr:  errors.New("go-git: WorktreeAdd() LockReason requires Lock"),
+		},
+	}
+	for _, c := range cases {
+		gotArgs := []string{}
+		execCommand = func(args ...string) runner {
+			gotArgs = args
+			return &mockRunner{}
+		}
+		_, gotErr := (&Repository{}).WorktreeAdd(c.Path, c.Commitish, c.Opts)
+		if !reflect.DeepEqual(c.ExpectArgs, gotArgs) || !equalErr(c.ExpectErr, gotErr) {
+			t.Errorf("%s\nexpected : %v, %v\ngot      : %v, %v",
+				c.CaseName,
+				c.ExpectArgs, c.ExpectErr,
+				gotArgs, gotErr,
+			)
+		}
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	out := "worktree /src/repo\x00HEAD aaaa\x00branch refs/heads/main\x00\x00" +
+		"worktree /src/v1\x00HEAD bbbb\x00detached\x00locked build in progress\x00\x00" +
+		"worktree /tmp/gone\x00HEAD cccc\x00branch refs/heads/old\x00prunable gitdir file points to non-existent location\x00\x00"
+	expect := []Worktree{
+		{Path: "/src/repo", Head: "aaaa", Branch: "refs/heads/main"},
+		{Path: "/src/v1", Head: "bbbb", Detached: true, Locked: true, LockReason: "build in progress"},
+		{Path: "/tmp/gone", Head: "cccc", Branch: "refs/heads/old", Prunable: true, PruneReason: "gitdir file points to non-existent location"},
+	}
+	if got := parseWorktreeList(out); !reflect.DeepEqual(expect, got) {
+		t.Errorf("expected : %+v\ngot      : %+v", expect, got)
+	}
+}
+
+func TestWorktrees(t *testing.T) {
+	dir := t.TempDir()
+	r := testRepository(t, filepath.Join(dir, "repo"))
+	testCommit(t, r, "first")
+
+	w, err := r.WorktreeAdd("../feature", "main", &WorktreeAddOptions{NewBranch: "feature"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, err := w.Head(); err != nil || h.Branch != "feature" {
+		t.Errorf("expected feature checked out in the worktree, got %+v, %v", h, err)
+	}
+	if _, err := r.WorktreeAdd(filepath.Join(dir, "detached"), "main", &WorktreeAddOptions{Detach: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.WorktreeLock("../detached", "build"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.WorktreeMove("../feature", "../moved", false); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees, err := r.Worktrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worktrees) != 3 {
+		t.Fatalf("expected 3 worktrees, got %+v", worktrees)
+	}
+	detached, moved := worktrees[1], worktrees[2]
+	if !detached.Detached || !detached.Locked || detached.LockReason != "build" {
+		t.Errorf("unexpected detached worktree %+v", detached)
+	}
+	if moved.Branch != "refs/heads/feature" || moved.Repository.Dir != moved.Path {
+		t.Errorf("unexpected moved worktree %+v", moved)
+	}
+
+	if err := r.WorktreeRemove("../detached", false); err == nil {
+		t.Error("expected removing a locked worktree to fail")
+	}
+	if err := r.WorktreeRemove("../detached", true); err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(moved.Path)
+	worktrees, _ = r.Worktrees()
+	if len(worktrees) != 2 || !worktrees[1].Prunable {
+		t.Errorf("expected the moved worktree to be prunable, got %+v", worktrees)
+	}
+	if err := r.WorktreePrune(); err != nil {
+		t.Fatal(err)
+	}
+	if worktrees, _ = r.Worktrees(); len(worktrees) != 1 {
+		t.Errorf("expected only the main worktree after pruning, got %+v", worktrees)
+	}
+}